@@ -61,9 +61,64 @@ type metadata struct {
 	UserLastUpdated string `json:"user_last_updated"`
 }
 
+func findRunner(cs *state, name string) *runner {
+	for _, i := range cs.runnerList {
+		if equal(i.Name, name) {
+			return i
+		}
+	}
+	return nil
+}
+
+// findUser matches a user by uuid, name or email.
+func findUser(cs *state, item string) *user {
+	for _, i := range cs.userList {
+		if equal(i.UUID, item) || equal(i.Name, item) || equal(i.Email, item) {
+			return i
+		}
+	}
+	return nil
+}
+
+// findGroup matches a group by uuid or name.
+func findGroup(cs *state, item string) *group {
+	for _, i := range cs.groupList {
+		if equal(i.UUID, item) || equal(i.Name, item) {
+			return i
+		}
+	}
+	return nil
+}
+
+func findSecret(cs *state, name string) *secret {
+	for _, i := range cs.secretList {
+		if equal(i.Name, name) {
+			return i
+		}
+	}
+	return nil
+}
+
+func findSource(cs *state, name string) *source {
+	for _, i := range cs.sourceList {
+		if equal(i.Name, name) {
+			return i
+		}
+	}
+	return nil
+}
+
+func findIntegration(cs *state, name string) *integration {
+	for _, i := range cs.integrationList {
+		if equal(i.Name, name) {
+			return i
+		}
+	}
+	return nil
+}
+
 func toAgent(a *entities.AgentModel, cs *state) (*agent, error) {
 	var err error
-	var validRunner bool
 
 	result := &agent{
 		Uuid:           a.Id.ValueString(),
@@ -93,14 +148,7 @@ func toAgent(a *entities.AgentModel, cs *state) (*agent, error) {
 		Starters: make([]starter, 0),
 	}
 
-	for _, v := range cs.runnerList {
-		item := a.Runner.ValueString()
-		if validRunner = equal(v.Name, item); validRunner {
-			break
-		}
-	}
-
-	if !validRunner {
+	if findRunner(cs, a.Runner.ValueString()) == nil {
 		item := a.Runner
 		err = errors.Join(err, eformat("runner \"%s\" don't exist", item))
 	}
@@ -140,17 +188,11 @@ func toAgent(a *entities.AgentModel, cs *state) (*agent, error) {
 
 	for _, v := range a.Users.Elements() {
 		if !v.IsNull() && !v.IsUnknown() {
-			found := false
 			str := v.String()
 			item := strings.ReplaceAll(str, "\"", "")
-			for _, i := range cs.userList {
-				if found = equal(i.Name, item) ||
-					equal(i.Email, item); found {
-					result.Users = append(result.Users, i.UUID)
-					break
-				}
-			}
-			if !found {
+			if i := findUser(cs, item); i != nil {
+				result.Users = append(result.Users, i.UUID)
+			} else {
 				err = errors.Join(err, fmt.Errorf("user \"%s\" don't exist", item))
 			}
 		}
@@ -158,69 +200,48 @@ func toAgent(a *entities.AgentModel, cs *state) (*agent, error) {
 
 	for _, v := range a.Groups.Elements() {
 		if !v.IsNull() && !v.IsUnknown() {
-			found := false
 			str := v.String()
 			item := strings.ReplaceAll(str, "\"", "")
-			for _, i := range cs.groupList {
-				if found = equal(i.Name, item); found {
-					result.Groups = append(result.Groups, i.UUID)
-					break
-				}
-			}
-			if !found {
-				err = errors.Join(err, fmt.Errorf("group \"%s\" don't exist", v))
+			if i := findGroup(cs, item); i != nil {
+				result.Groups = append(result.Groups, i.UUID)
+			} else {
+				err = errors.Join(err, fmt.Errorf("group \"%s\" don't exist", item))
 			}
 		}
 	}
 
 	for _, v := range a.Secrets.Elements() {
 		if !v.IsNull() && !v.IsUnknown() {
-			found := false
 			str := v.String()
 			item := strings.ReplaceAll(str, "\"", "")
-			for _, i := range cs.secretList {
-				if found = equal(i.Name, item); found {
-					result.Secrets = append(result.Secrets, i.Name)
-					break
-				}
-			}
-			if !found {
-				err = errors.Join(err, fmt.Errorf("secret \"%s\" don't exist", v))
+			if i := findSecret(cs, item); i != nil {
+				result.Secrets = append(result.Secrets, i.Name)
+			} else {
+				err = errors.Join(err, fmt.Errorf("secret \"%s\" don't exist", item))
 			}
 		}
 	}
 
 	for _, v := range a.Sources.Elements() {
 		if !v.IsNull() && !v.IsUnknown() {
-			found := false
 			str := v.String()
 			item := strings.ReplaceAll(str, "\"", "")
-			for _, i := range cs.sourceList {
-				if found = equal(i.Name, item); found {
-					result.Sources = append(result.Sources, i.Id)
-					break
-				}
-			}
-			if !found {
-				err = errors.Join(err, fmt.Errorf("source \"%s\" don't exist", v))
+			if i := findSource(cs, item); i != nil {
+				result.Sources = append(result.Sources, i.Id)
+			} else {
+				err = errors.Join(err, fmt.Errorf("source \"%s\" don't exist", item))
 			}
 		}
 	}
 
 	for _, v := range a.Integrations.Elements() {
 		if !v.IsNull() && !v.IsUnknown() {
-			found := false
 			str := v.String()
 			item := strings.ReplaceAll(str, "\"", "")
-			for _, i := range cs.integrationList {
-				if found = equal(i.Name, item); found {
-					result.Integrations = append(result.Integrations, i.Name)
-					break
-				}
-			}
-
-			if !found {
-				err = errors.Join(err, fmt.Errorf("integration \"%s\" don't exist", v))
+			if i := findIntegration(cs, item); i != nil {
+				result.Integrations = append(result.Integrations, i.Name)
+			} else {
+				err = errors.Join(err, fmt.Errorf("integration \"%s\" don't exist", item))
 			}
 		}
 	}
@@ -238,7 +259,9 @@ func toAgent(a *entities.AgentModel, cs *state) (*agent, error) {
 	return result, err
 }
 
-func fromAgent(a *agent, cs *state) (*entities.AgentModel, error) {
+// fromAgent maps an api agent to its model. Users and groups keep the spelling
+// (uuid, name or email) used by prior when it refers to the same object, prior may be nil.
+func fromAgent(a *agent, cs *state, prior *entities.AgentModel) (*entities.AgentModel, error) {
 	var err error
 	result := &entities.AgentModel{
 		Id:           types.StringValue(a.Uuid),
@@ -290,10 +313,17 @@ func fromAgent(a *agent, cs *state) (*entities.AgentModel, error) {
 		}
 	}
 
+	priorUsers := types.ListNull(types.StringType)
+	priorGroups := types.ListNull(types.StringType)
+	if prior != nil {
+		priorUsers = prior.Users
+		priorGroups = prior.Groups
+	}
+
 	for _, t := range a.Users {
 		for _, u := range cs.userList {
 			if equal(u.UUID, t) {
-				usersList = append(usersList, u.Email)
+				usersList = append(usersList, reference(priorUsers, u.Email, u.UUID, u.Email, u.Name))
 				break
 			}
 		}
@@ -302,7 +332,7 @@ func fromAgent(a *agent, cs *state) (*entities.AgentModel, error) {
 	for _, t := range a.Groups {
 		for _, g := range cs.groupList {
 			if equal(g.UUID, t) {
-				groupList = append(groupList, g.Name)
+				groupList = append(groupList, reference(priorGroups, g.Name, g.UUID, g.Name))
 				break
 			}
 		}
@@ -336,12 +366,51 @@ func fromAgent(a *agent, cs *state) (*entities.AgentModel, error) {
 	return result, err
 }
 
+// agentStateKinds returns the lists toAgent and fromAgent resolve for the given model.
+func agentStateKinds(a *entities.AgentModel) []stateKind {
+	kinds := []stateKind{usersKind, runnersKind, modelsKind}
+
+	if len(a.Groups.Elements()) >= 1 {
+		kinds = append(kinds, groupsKind)
+	}
+
+	if len(a.Secrets.Elements()) >= 1 {
+		kinds = append(kinds, secretsKind)
+	}
+
+	if len(a.Sources.Elements()) >= 1 {
+		kinds = append(kinds, sourcesKind)
+	}
+
+	if len(a.Integrations.Elements()) >= 1 {
+		kinds = append(kinds, integrationsKind)
+	}
+
+	return kinds
+}
+
+// agentResponseKinds returns the lists fromAgent resolves for the given api agent.
+func agentResponseKinds(a *agent) []stateKind {
+	kinds := []stateKind{usersKind}
+
+	if len(a.Groups) >= 1 {
+		kinds = append(kinds, groupsKind)
+	}
+
+	if len(a.Sources) >= 1 {
+		kinds = append(kinds, sourcesKind)
+	}
+
+	return kinds
+}
+
 func (c *Client) DeleteAgent(ctx context.Context, e *entities.AgentModel) error {
 	if e != nil {
 		id := e.Id.ValueString()
 		path := format("/api/v1/agents/%s", id)
 
 		_, err := c.delete(ctx, c.uri(path))
+		c.cache.invalidate(agentsKind)
 		return err
 	}
 
@@ -350,7 +419,7 @@ func (c *Client) DeleteAgent(ctx context.Context, e *entities.AgentModel) error
 
 func (c *Client) UpdateAgent(ctx context.Context, e *entities.AgentModel) error {
 	if e != nil {
-		cs, err := c.state()
+		cs, err := c.state(ctx, agentStateKinds(e)...)
 		if err != nil {
 			return err
 		}
@@ -372,6 +441,7 @@ func (c *Client) UpdateAgent(ctx context.Context, e *entities.AgentModel) error
 		}
 
 		resp, err := c.update(ctx, uri, body)
+		c.cache.invalidate(agentsKind)
 		if err != nil {
 			return err
 		}
@@ -382,18 +452,14 @@ func (c *Client) UpdateAgent(ctx context.Context, e *entities.AgentModel) error
 			return err
 		}
 
-		e, err = fromAgent(r, cs)
+		e, err = fromAgent(r, cs, e)
 		return err
 	}
 	return fmt.Errorf("param entity (*entities.AgentModel) is nil")
 }
 
-func (c *Client) ReadAgent(ctx context.Context, id string) (*entities.AgentModel, error) {
-	cs, err := c.state()
-	if err != nil {
-		return nil, err
-	}
-
+// ReadAgent reads the agent with the given id. prior is the last known state, it may be nil.
+func (c *Client) ReadAgent(ctx context.Context, id string, prior *entities.AgentModel) (*entities.AgentModel, error) {
 	path := format("/api/v1/agents/%s", id)
 
 	resp, err := c.read(ctx, c.uri(path))
@@ -407,12 +473,21 @@ func (c *Client) ReadAgent(ctx context.Context, id string) (*entities.AgentModel
 		return nil, err
 	}
 
-	entity, err := fromAgent(r, cs)
+	if r == nil {
+		return nil, eformat("Agent %s %w", id, ErrNotFound)
+	}
+
+	cs, err := c.state(ctx, agentResponseKinds(r)...)
+	if err != nil {
+		return nil, err
+	}
+
+	entity, err := fromAgent(r, cs, prior)
 	if err != nil || entity == nil {
 		if err != nil {
 			return nil, err
 		}
-		return nil, eformat("Agent %s not found", id)
+		return nil, eformat("Agent %s %w", id, ErrNotFound)
 	}
 
 	return entity, nil
@@ -420,7 +495,7 @@ func (c *Client) ReadAgent(ctx context.Context, id string) (*entities.AgentModel
 
 func (c *Client) CreateAgent(ctx context.Context, e *entities.AgentModel) (*entities.AgentModel, error) {
 	if e != nil {
-		cs, err := c.state()
+		cs, err := c.state(ctx, agentStateKinds(e)...)
 		if err != nil {
 			return nil, err
 		}
@@ -440,6 +515,7 @@ func (c *Client) CreateAgent(ctx context.Context, e *entities.AgentModel) (*enti
 		uri := c.uri("/api/v1/agents")
 
 		resp, err := c.create(ctx, uri, body)
+		c.cache.invalidate(agentsKind)
 		if err != nil {
 			return nil, err
 		}
@@ -450,8 +526,131 @@ func (c *Client) CreateAgent(ctx context.Context, e *entities.AgentModel) (*enti
 			return nil, err
 		}
 
-		return fromAgent(r, cs)
+		return fromAgent(r, cs, e)
 	}
 
 	return e, fmt.Errorf("param entity (*entities.AgentModel) is nil")
 }
+
+// FindAgent returns the agent with the given id or, when id is empty, the only agent named name.
+func (c *Client) FindAgent(ctx context.Context, id, name string) (*entities.AgentModel, error) {
+	if len(id) >= 1 {
+		return c.ReadAgent(ctx, id, nil)
+	}
+
+	cs, err := c.state(ctx, agentsKind)
+	if err != nil {
+		return nil, err
+	}
+
+	var match *agent
+	for _, a := range cs.agentList {
+		if equal(a.Name, name) {
+			if match != nil {
+				return nil, eformat("more than one agent is named \"%s\". look it up by id instead", name)
+			}
+			match = a
+		}
+	}
+
+	if match == nil {
+		return nil, eformat("Agent \"%s\" %w", name, ErrNotFound)
+	}
+
+	refs, err := c.state(ctx, agentResponseKinds(match)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return fromAgent(match, refs, nil)
+}
+
+// ListAgents returns every agent of the organization ordered by name.
+func (c *Client) ListAgents(ctx context.Context) ([]*entities.AgentModel, error) {
+	cs, err := c.state(ctx, agentsKind, usersKind, groupsKind, sourcesKind)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entities.AgentModel, 0, len(cs.agentList))
+	for _, a := range cs.agentList {
+		entity, err := fromAgent(a, cs, nil)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, entity)
+	}
+
+	slices.SortStableFunc(result, func(a, b *entities.AgentModel) int {
+		return strings.Compare(a.Name.ValueString(), b.Name.ValueString())
+	})
+
+	return result, nil
+}
+
+// AgentReference is a reference of an agent to another object, e.g. a runner or a user.
+// Attribute is the agent attribute holding the reference and Index its position in that list.
+type AgentReference struct {
+	Attribute string
+	Index     int
+	Value     string
+}
+
+var agentReferenceKinds = map[string]stateKind{
+	"runner":       runnersKind,
+	"users":        usersKind,
+	"groups":       groupsKind,
+	"secrets":      secretsKind,
+	"sources":      sourcesKind,
+	"integrations": integrationsKind,
+}
+
+func resolveAgentReference(cs *state, r AgentReference) bool {
+	switch r.Attribute {
+	case "runner":
+		return findRunner(cs, r.Value) != nil
+	case "users":
+		return findUser(cs, r.Value) != nil
+	case "groups":
+		return findGroup(cs, r.Value) != nil
+	case "secrets":
+		return findSecret(cs, r.Value) != nil
+	case "sources":
+		return findSource(cs, r.Value) != nil
+	case "integrations":
+		return findIntegration(cs, r.Value) != nil
+	}
+	return false
+}
+
+// MissingAgentReferences returns the references that don't resolve, the same way toAgent resolves them on apply.
+func (c *Client) MissingAgentReferences(ctx context.Context, refs []AgentReference) ([]AgentReference, error) {
+	var kinds []stateKind
+	for _, r := range refs {
+		kind, ok := agentReferenceKinds[r.Attribute]
+		if !ok {
+			return nil, eformat("agent attribute \"%s\" doesn't hold references", r.Attribute)
+		}
+		if !slices.Contains(kinds, kind) {
+			kinds = append(kinds, kind)
+		}
+	}
+
+	if len(kinds) == 0 {
+		return nil, nil
+	}
+
+	cs, err := c.state(ctx, kinds...)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []AgentReference
+	for _, r := range refs {
+		if !resolveAgentReference(cs, r) {
+			missing = append(missing, r)
+		}
+	}
+
+	return missing, nil
+}
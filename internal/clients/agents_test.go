@@ -0,0 +1,127 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-kubiya/internal/entities"
+)
+
+func stringList(t *testing.T, items ...string) types.List {
+	t.Helper()
+
+	elements := make([]attr.Value, 0, len(items))
+	for _, item := range items {
+		elements = append(elements, types.StringValue(item))
+	}
+
+	list, diags := types.ListValue(types.StringType, elements)
+	if diags.HasError() {
+		t.Fatalf("ListValue() = %v", diags)
+	}
+
+	return list
+}
+
+func TestAgentReferencesKeepConfiguredSpelling(t *testing.T) {
+	cs := &state{
+		userList: []*user{
+			{UUID: "u1", Name: "Alice", Email: "alice@example.com"},
+			{UUID: "u2", Name: "Bob", Email: "bob@example.com"},
+			{UUID: "u3", Name: "Carol", Email: "carol@example.com"},
+		},
+		groupList: []*group{
+			{UUID: "g1", Name: "Admins"},
+			{UUID: "g2", Name: "DevOps"},
+		},
+		runnerList: []*runner{{Name: "runner"}},
+		modelList:  []string{"gpt-4o"},
+	}
+
+	model := &entities.AgentModel{
+		Name:   types.StringValue("agent"),
+		Runner: types.StringValue("runner"),
+		Model:  types.StringValue("gpt-4o"),
+		Users:  stringList(t, "u1", "Bob", "carol@example.com"),
+		Groups: stringList(t, "g1", "devops"),
+	}
+
+	data, err := toAgent(model, cs)
+	if err != nil {
+		t.Fatalf("toAgent() error = %v", err)
+	}
+
+	if want := []string{"u1", "u2", "u3"}; !slices.Equal(data.Users, want) {
+		t.Errorf("users resolved to %v, want %v", data.Users, want)
+	}
+
+	if want := []string{"g1", "g2"}; !slices.Equal(data.Groups, want) {
+		t.Errorf("groups resolved to %v, want %v", data.Groups, want)
+	}
+
+	result, err := fromAgent(data, cs, model)
+	if err != nil {
+		t.Fatalf("fromAgent() error = %v", err)
+	}
+
+	if !result.Users.Equal(model.Users) {
+		t.Errorf("users = %v, want %v", result.Users, model.Users)
+	}
+
+	if !result.Groups.Equal(model.Groups) {
+		t.Errorf("groups = %v, want %v", result.Groups, model.Groups)
+	}
+
+	imported, err := fromAgent(data, cs, nil)
+	if err != nil {
+		t.Fatalf("fromAgent() error = %v", err)
+	}
+
+	if want := stringList(t, "alice@example.com", "bob@example.com", "carol@example.com"); !imported.Users.Equal(want) {
+		t.Errorf("users without prior state = %v, want %v", imported.Users, want)
+	}
+}
+
+func TestMissingAgentReferences(t *testing.T) {
+	lists := map[string]string{
+		"/api/v3/runners":       `[{"name":"prod"}]`,
+		"/api/v1/users":         `[{"uuid":"u1","name":"Alice","email":"alice@example.com"}]`,
+		"/api/v1/manage/groups": `[{"uuid":"g1","name":"Admins"}]`,
+	}
+	srv, ls := newListServer(t, func(w http.ResponseWriter, r *http.Request, _ int) {
+		_, _ = w.Write([]byte(lists[r.URL.Path]))
+	})
+	c := newTestClient(t, Config{Environment: srv.URL, StateCacheTTL: time.Minute})
+
+	refs := []AgentReference{
+		{Attribute: "runner", Value: "PROD"},
+		{Attribute: "runner", Value: "staging"},
+		{Attribute: "users", Index: 0, Value: "alice@example.com"},
+		{Attribute: "users", Index: 1, Value: "bob@example.com"},
+		{Attribute: "groups", Index: 0, Value: "admins"},
+	}
+
+	missing, err := c.MissingAgentReferences(context.Background(), refs)
+	if err != nil {
+		t.Fatalf("MissingAgentReferences() error = %v", err)
+	}
+
+	want := []AgentReference{refs[1], refs[3]}
+	if !slices.Equal(missing, want) {
+		t.Errorf("missing = %v, want %v", missing, want)
+	}
+
+	if n := ls.count("GET /api/v2/secrets"); n != 0 {
+		t.Errorf("secrets were loaded %d times without any secret reference", n)
+	}
+
+	if _, err := c.MissingAgentReferences(context.Background(), []AgentReference{{Attribute: "tools", Value: "x"}}); err == nil {
+		t.Error("MissingAgentReferences() accepted an attribute that holds no references")
+	}
+}
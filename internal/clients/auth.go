@@ -0,0 +1,289 @@
+package clients
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// The authentication modes of AuthConfig.Type.
+const (
+	AuthApiKey    = "api_key"
+	AuthBearer    = "bearer"
+	AuthTokenFile = "token_file"
+	AuthOIDC      = "oidc"
+)
+
+// expirySkew is how long before its expiry a token is replaced, so it does not
+// expire while a request is in flight.
+const expirySkew = 30 * time.Second
+
+// AuthConfig selects how the api calls authenticate. The zero value sends Config.ApiKey.
+type AuthConfig struct {
+	Type string
+
+	// Token is the bearer token, or the oidc token exchanged for a kubiya key.
+	Token string
+
+	// TokenFile holds the bearer token, or the oidc token exchanged for a kubiya
+	// key. It is read again whenever the token expires or the api rejects it.
+	TokenFile string
+
+	// TokenExchangeUrl is the RFC 8693 endpoint exchanging the oidc token for a short-lived key.
+	TokenExchangeUrl string
+}
+
+// credentials produce the Authorization header of the api calls.
+type credentials interface {
+	authorization(ctx context.Context) (string, error)
+
+	// reject drops a token the api answered 401 to. It reports whether the
+	// next call uses another token, i.e. whether retrying may succeed.
+	reject() bool
+}
+
+func newCredentials(cfg Config, client *http.Client, userAgent string) (credentials, error) {
+	auth := cfg.Auth
+
+	switch auth.Type {
+	case "", AuthApiKey:
+		if len(cfg.ApiKey) == 0 {
+			return nil, eformat("ApiKey is missing or empty")
+		}
+		return staticCredentials(format("UserKey %s", cfg.ApiKey)), nil
+	case AuthBearer:
+		if len(auth.Token) == 0 {
+			return nil, eformat("the bearer auth requires a token")
+		}
+		return staticCredentials(format("Bearer %s", auth.Token)), nil
+	case AuthTokenFile:
+		if len(auth.TokenFile) == 0 {
+			return nil, eformat("the token_file auth requires a token file")
+		}
+		return &fileCredentials{path: auth.TokenFile}, nil
+	case AuthOIDC:
+		if (len(auth.Token) == 0) == (len(auth.TokenFile) == 0) {
+			return nil, eformat("the oidc auth requires exactly one of token and token file")
+		}
+		if _, err := url.ParseRequestURI(auth.TokenExchangeUrl); err != nil {
+			return nil, eformat("the oidc auth requires a valid token exchange url. %w", err)
+		}
+		return &oidcCredentials{
+			token:     auth.Token,
+			tokenFile: auth.TokenFile,
+			url:       auth.TokenExchangeUrl,
+			client:    client,
+			userAgent: userAgent,
+		}, nil
+	}
+
+	return nil, eformat("auth type \"%s\" is not valid. use %s, %s, %s or %s",
+		auth.Type, AuthApiKey, AuthBearer, AuthTokenFile, AuthOIDC)
+}
+
+// staticCredentials is a header that never changes, an api key or a bearer token.
+type staticCredentials string
+
+func (s staticCredentials) authorization(context.Context) (string, error) {
+	return string(s), nil
+}
+
+func (s staticCredentials) reject() bool {
+	return false
+}
+
+// fileCredentials send the bearer token found in a file, e.g. a workload identity
+// token a ci system rotates in place.
+type fileCredentials struct {
+	path string
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+func (f *fileCredentials) authorization(context.Context) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if len(f.token) == 0 || expired(f.expiry) {
+		token, err := readToken(f.path)
+		if err != nil {
+			return "", err
+		}
+
+		f.token, f.expiry = token, jwtExpiry(token)
+	}
+
+	return format("Bearer %s", f.token), nil
+}
+
+func (f *fileCredentials) reject() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.token = ""
+	return true
+}
+
+// oidcCredentials exchange an oidc token for a short-lived kubiya key, and
+// exchange it again once the key expires.
+type oidcCredentials struct {
+	token     string
+	tokenFile string
+	url       string
+	client    *http.Client
+	userAgent string
+
+	mu     sync.Mutex
+	header string
+	expiry time.Time
+}
+
+// tokenExchange is the RFC 8693 response of the token exchange url.
+type tokenExchange struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+func (o *oidcCredentials) authorization(ctx context.Context) (string, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if len(o.header) >= 1 && !expired(o.expiry) {
+		return o.header, nil
+	}
+
+	subject := o.token
+	if len(o.tokenFile) >= 1 {
+		token, err := readToken(o.tokenFile)
+		if err != nil {
+			return "", err
+		}
+		subject = token
+	}
+
+	result, err := o.exchange(ctx, subject)
+	if err != nil {
+		return "", err
+	}
+
+	o.header = format("UserKey %s", result.AccessToken)
+	if strings.EqualFold(result.TokenType, "bearer") {
+		o.header = format("Bearer %s", result.AccessToken)
+	}
+
+	o.expiry = jwtExpiry(result.AccessToken)
+	if result.ExpiresIn > 0 {
+		o.expiry = time.Now().Add(time.Duration(result.ExpiresIn) * time.Second)
+	}
+
+	tflog.SubsystemDebug(ctx, logSubsystem, "Exchanged the OIDC token for a Kubiya key", map[string]any{
+		"url":        o.url,
+		"expires_at": o.expiry.Format(time.RFC3339),
+	})
+
+	return o.header, nil
+}
+
+func (o *oidcCredentials) exchange(ctx context.Context, subject string) (*tokenExchange, error) {
+	form := url.Values{
+		"grant_type":         {"urn:ietf:params:oauth:grant-type:token-exchange"},
+		"subject_token":      {subject},
+		"subject_token_type": {"urn:ietf:params:oauth:token-type:jwt"},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", o.userAgent)
+
+	// the exchange goes straight to the transport, neither the oidc token nor
+	// the key it returns are ever logged
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, eformat("failed to exchange the oidc token. %w", err)
+	}
+	defer closeBody(resp.Body)
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, eformat("failed to exchange the oidc token. %w", newAPIError(req, resp, b))
+	}
+
+	var result tokenExchange
+	if err := json.Unmarshal(b, &result); err != nil {
+		return nil, eformat("failed to decode the token exchange response. %w", err)
+	}
+
+	if len(result.AccessToken) == 0 {
+		return nil, eformat("the token exchange response has no access_token")
+	}
+
+	return &result, nil
+}
+
+func (o *oidcCredentials) reject() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.header = ""
+	return true
+}
+
+func readToken(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", eformat("failed to read token file. %w", err)
+	}
+
+	token := strings.TrimSpace(string(b))
+	if len(token) == 0 {
+		return "", eformat("token file \"%s\" is empty", path)
+	}
+
+	return token, nil
+}
+
+func expired(expiry time.Time) bool {
+	return !expiry.IsZero() && time.Now().Add(expirySkew).After(expiry)
+}
+
+// jwtExpiry returns the exp claim of token, or the zero time when token is not
+// a jwt. The signature is not checked, the api does that.
+func jwtExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}
+	}
+
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(b, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(claims.Exp, 0)
+}
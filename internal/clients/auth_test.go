@@ -0,0 +1,223 @@
+package clients
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// jwt returns an unsigned jwt expiring at exp.
+func jwt(subject string, exp time.Time) string {
+	encode := base64.RawURLEncoding.EncodeToString
+
+	return strings.Join([]string{
+		encode([]byte(`{"alg":"none"}`)),
+		encode([]byte(fmt.Sprintf(`{"sub":%q,"exp":%d}`, subject, exp.Unix()))),
+		"signature",
+	}, ".")
+}
+
+func writeToken(t *testing.T, file, token string) {
+	t.Helper()
+
+	if err := os.WriteFile(file, []byte(token+"\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+}
+
+// authServer records the Authorization header of every api call and answers 401
+// to the headers not in accepted.
+type authServer struct {
+	mu       sync.Mutex
+	accepted map[string]bool
+	headers  []string
+}
+
+func (s *authServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	header := r.Header.Get("Authorization")
+	s.headers = append(s.headers, header)
+
+	if !s.accepted[header] {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	_, _ = w.Write([]byte(`{}`))
+}
+
+func (s *authServer) accept(headers ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.accepted = make(map[string]bool)
+	for _, h := range headers {
+		s.accepted[h] = true
+	}
+}
+
+func TestBearerAuth(t *testing.T) {
+	api := &authServer{}
+	api.accept("Bearer static")
+	srv, _ := newListServer(t, func(w http.ResponseWriter, r *http.Request, _ int) { api.ServeHTTP(w, r) })
+
+	c := newTestClient(t, Config{Environment: srv.URL, Auth: AuthConfig{Type: AuthBearer, Token: "static"}})
+
+	if _, err := c.read(context.Background(), c.uri("/api/v1/users/self")); err != nil {
+		t.Errorf("read() error = %v", err)
+	}
+}
+
+func TestTokenFileIsReadAgainOnExpiry(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "token")
+	expiring := jwt("first", time.Now().Add(10*time.Second))
+	writeToken(t, file, expiring)
+
+	api := &authServer{}
+	api.accept("Bearer " + expiring)
+	srv, _ := newListServer(t, func(w http.ResponseWriter, r *http.Request, _ int) { api.ServeHTTP(w, r) })
+
+	c := newTestClient(t, Config{Environment: srv.URL, Auth: AuthConfig{Type: AuthTokenFile, TokenFile: file}})
+
+	if _, err := c.read(context.Background(), c.uri("/api/v1/users/self")); err != nil {
+		t.Fatalf("read() error = %v", err)
+	}
+
+	// the token expires within the skew, so it is replaced before the next call
+	fresh := jwt("second", time.Now().Add(time.Hour))
+	writeToken(t, file, fresh)
+	api.accept("Bearer " + fresh)
+
+	if _, err := c.read(context.Background(), c.uri("/api/v1/users/self")); err != nil {
+		t.Fatalf("read() error = %v", err)
+	}
+
+	want := []string{"Bearer " + expiring, "Bearer " + fresh}
+	if fmt.Sprint(api.headers) != fmt.Sprint(want) {
+		t.Errorf("headers = %v, want %v", api.headers, want)
+	}
+}
+
+func TestTokenFileIsReadAgainWhenRejected(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "token")
+	writeToken(t, file, "first")
+
+	api := &authServer{}
+	api.accept("Bearer first")
+	srv, _ := newListServer(t, func(w http.ResponseWriter, r *http.Request, _ int) { api.ServeHTTP(w, r) })
+
+	c := newTestClient(t, Config{Environment: srv.URL, Auth: AuthConfig{Type: AuthTokenFile, TokenFile: file}})
+
+	if _, err := c.read(context.Background(), c.uri("/api/v1/users/self")); err != nil {
+		t.Fatalf("read() error = %v", err)
+	}
+
+	// the token carries no expiry, it is only replaced once the api rejects it
+	writeToken(t, file, "second")
+	api.accept("Bearer second")
+
+	if _, err := c.create(context.Background(), c.uri("/api/v1/agents"), strings.NewReader(`{}`)); err != nil {
+		t.Fatalf("create() error = %v", err)
+	}
+
+	want := []string{"Bearer first", "Bearer first", "Bearer second"}
+	if fmt.Sprint(api.headers) != fmt.Sprint(want) {
+		t.Errorf("headers = %v, want %v", api.headers, want)
+	}
+
+	// a token the api keeps rejecting fails the call instead of looping
+	api.accept()
+
+	if _, err := c.read(context.Background(), c.uri("/api/v1/users/self")); !strings.Contains(fmt.Sprint(err), "401") {
+		t.Errorf("read() error = %v, want a 401", err)
+	}
+}
+
+func TestOIDCExchange(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "token")
+	writeToken(t, file, "oidc-token")
+
+	api := &authServer{}
+	api.accept("UserKey key-1")
+
+	srv, ls := newListServer(t, func(w http.ResponseWriter, r *http.Request, n int) {
+		if r.URL.Path != "/token" {
+			api.ServeHTTP(w, r)
+			return
+		}
+
+		_ = r.ParseForm()
+		if r.PostForm.Get("subject_token") != "oidc-token" ||
+			r.PostForm.Get("grant_type") != "urn:ietf:params:oauth:grant-type:token-exchange" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		_, _ = fmt.Fprintf(w, `{"access_token":"key-%d","token_type":"N_A","expires_in":3600}`, n)
+	})
+
+	c := newTestClient(t, Config{Environment: srv.URL, Auth: AuthConfig{Type: AuthOIDC, TokenFile: file, TokenExchangeUrl: srv.URL + "/token"}})
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.read(context.Background(), c.uri("/api/v1/users/self")); err != nil {
+			t.Fatalf("read() error = %v", err)
+		}
+	}
+
+	if n := ls.count("POST /token"); n != 1 {
+		t.Errorf("the oidc token was exchanged %d times, want 1 while the key is valid", n)
+	}
+
+	// a revoked key is exchanged again
+	api.accept("UserKey key-2")
+
+	if _, err := c.read(context.Background(), c.uri("/api/v1/users/self")); err != nil {
+		t.Fatalf("read() error = %v", err)
+	}
+
+	if n := ls.count("POST /token"); n != 2 {
+		t.Errorf("the oidc token was exchanged %d times, want 2 after the key was rejected", n)
+	}
+
+	writeToken(t, file, "another-token")
+	api.accept()
+
+	_, err := c.read(context.Background(), c.uri("/api/v1/users/self"))
+	if err == nil || !strings.Contains(err.Error(), "failed to exchange the oidc token") {
+		t.Errorf("read() error = %v, want the failed exchange", err)
+	}
+}
+
+func TestInvalidAuthConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{name: "api key", cfg: Config{}, want: "ApiKey is missing"},
+		{name: "bearer", cfg: Config{Auth: AuthConfig{Type: AuthBearer}}, want: "requires a token"},
+		{name: "token file", cfg: Config{Auth: AuthConfig{Type: AuthTokenFile}}, want: "requires a token file"},
+		{name: "oidc without token", cfg: Config{Auth: AuthConfig{Type: AuthOIDC, TokenExchangeUrl: "https://sts.example.com"}}, want: "exactly one"},
+		{name: "oidc with both tokens", cfg: Config{Auth: AuthConfig{Type: AuthOIDC, Token: "t", TokenFile: "f", TokenExchangeUrl: "https://sts.example.com"}}, want: "exactly one"},
+		{name: "oidc without exchange url", cfg: Config{Auth: AuthConfig{Type: AuthOIDC, Token: "t"}}, want: "token exchange url"},
+		{name: "unknown type", cfg: Config{Auth: AuthConfig{Type: "basic"}}, want: "not valid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("New() error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
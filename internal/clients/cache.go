@@ -0,0 +1,110 @@
+package clients
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultStateCacheTTL is how long org wide lists are reused unless configured otherwise.
+const DefaultStateCacheTTL = 5 * time.Minute
+
+// stateKind identifies one of the org wide lists that make up a state snapshot.
+type stateKind int
+
+const (
+	usersKind stateKind = iota
+	agentsKind
+	groupsKind
+	modelsKind
+	runnersKind
+	secretsKind
+	sourcesKind
+	webhooksKind
+	integrationsKind
+	knowledgeKind
+	externalKnowledgeKind
+	channelsKind
+)
+
+type cacheEntry struct {
+	mu      sync.Mutex
+	value   any
+	expires time.Time
+}
+
+// stateCache keeps the lists fetched by state() for the lifetime of a provider
+// instance. Concurrent lookups of the same kind share a single API call.
+type stateCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[stateKind]*cacheEntry
+}
+
+func newStateCache(ttl time.Duration) *stateCache {
+	return &stateCache{
+		ttl:     ttl,
+		entries: make(map[stateKind]*cacheEntry),
+	}
+}
+
+func (s *stateCache) entry(kind stateKind) *cacheEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[kind]
+	if !ok {
+		e = &cacheEntry{}
+		s.entries[kind] = e
+	}
+
+	return e
+}
+
+func (s *stateCache) get(kind stateKind, fetch func() (any, error)) (any, error) {
+	e := s.entry(kind)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.value != nil && time.Now().Before(e.expires) {
+		return e.value, nil
+	}
+
+	value, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	if s.ttl > 0 {
+		e.value = value
+		e.expires = time.Now().Add(s.ttl)
+	}
+
+	return value, nil
+}
+
+// invalidate drops the cached lists of the given kinds, it is called after
+// every write so later reads in the same run observe the change.
+func (s *stateCache) invalidate(kinds ...stateKind) {
+	for _, kind := range kinds {
+		e := s.entry(kind)
+
+		e.mu.Lock()
+		e.value = nil
+		e.expires = time.Time{}
+		e.mu.Unlock()
+	}
+}
+
+func cached[T any](ctx context.Context, s *stateCache, kind stateKind, fetch func(context.Context) ([]T, error)) ([]T, error) {
+	value, err := s.get(kind, func() (any, error) {
+		return fetch(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items, _ := value.([]T)
+	return append(make([]T, 0, len(items)), items...), nil
+}
@@ -0,0 +1,203 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-kubiya/internal/entities"
+)
+
+// listServer serves org wide lists and counts the GET calls made per path.
+type listServer struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func newListServer(t *testing.T, handler func(w http.ResponseWriter, r *http.Request, n int)) (*httptest.Server, *listServer) {
+	t.Helper()
+
+	ls := &listServer{calls: make(map[string]int)}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ls.mu.Lock()
+		ls.calls[r.Method+" "+r.URL.Path]++
+		n := ls.calls[r.Method+" "+r.URL.Path]
+		ls.mu.Unlock()
+
+		handler(w, r, n)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, ls
+}
+
+func (ls *listServer) count(key string) int {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	return ls.calls[key]
+}
+
+func TestConcurrentStateSharesOneCall(t *testing.T) {
+	srv, ls := newListServer(t, func(w http.ResponseWriter, _ *http.Request, _ int) {
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(`[{"uuid":"u1","email":"a@b.c"}]`))
+	})
+	c := newTestClient(t, Config{Environment: srv.URL, StateCacheTTL: time.Minute})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			cs, err := c.state(context.Background(), usersKind)
+			if err != nil || len(cs.userList) != 1 {
+				t.Errorf("state() = %v, %v", cs, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := ls.count("GET /api/v1/users"); got != 1 {
+		t.Errorf("expected 1 call, got %d", got)
+	}
+}
+
+func TestWriteInvalidatesCachedList(t *testing.T) {
+	srv, ls := newListServer(t, func(w http.ResponseWriter, r *http.Request, _ int) {
+		if r.Method == http.MethodDelete {
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"uuid":"a1","name":"agent"}]`))
+	})
+	c := newTestClient(t, Config{Environment: srv.URL, StateCacheTTL: time.Minute})
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.state(context.Background(), agentsKind); err != nil {
+			t.Fatalf("state() error = %v", err)
+		}
+	}
+
+	if got := ls.count("GET /api/v1/agents"); got != 1 {
+		t.Fatalf("expected the second read to be cached, got %d calls", got)
+	}
+
+	if err := c.DeleteAgent(context.Background(), &entities.AgentModel{Id: types.StringValue("a1")}); err != nil {
+		t.Fatalf("DeleteAgent() error = %v", err)
+	}
+
+	if _, err := c.state(context.Background(), agentsKind); err != nil {
+		t.Fatalf("state() error = %v", err)
+	}
+
+	if got := ls.count("GET /api/v1/agents"); got != 2 {
+		t.Errorf("expected a refetch after the write, got %d calls", got)
+	}
+}
+
+func TestZeroTTLNeverServesStaleData(t *testing.T) {
+	srv, ls := newListServer(t, func(w http.ResponseWriter, _ *http.Request, n int) {
+		_, _ = fmt.Fprintf(w, `[{"name":"secret-%d"}]`, n)
+	})
+	c := newTestClient(t, Config{Environment: srv.URL, StateCacheTTL: 0})
+
+	for i := 1; i <= 3; i++ {
+		cs, err := c.state(context.Background(), secretsKind)
+		if err != nil {
+			t.Fatalf("state() error = %v", err)
+		}
+
+		if want := fmt.Sprintf("secret-%d", i); len(cs.secretList) != 1 || cs.secretList[0].Name != want {
+			t.Errorf("call %d: expected %s, got %v", i, want, cs.secretList)
+		}
+	}
+
+	if got := ls.count("GET /api/v2/secrets"); got != 3 {
+		t.Errorf("expected 3 calls, got %d", got)
+	}
+}
+
+func TestPartialStateFailureKeepsLoadedLists(t *testing.T) {
+	srv, _ := newListServer(t, func(w http.ResponseWriter, r *http.Request, _ int) {
+		switch r.URL.Path {
+		case "/api/v1/manage/groups":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "/api/v1/sources":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			_, _ = w.Write([]byte(`[{"uuid":"u1","name":"user"}]`))
+		}
+	})
+	c := newTestClient(t, Config{Environment: srv.URL, StateCacheTTL: time.Minute})
+
+	cs, err := c.state(context.Background(), usersKind, groupsKind, sourcesKind, runnersKind)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	for _, path := range []string{"/api/v1/manage/groups", "/api/v1/sources"} {
+		if !strings.Contains(err.Error(), path) {
+			t.Errorf("error %q does not mention %s", err, path)
+		}
+	}
+
+	if len(cs.userList) != 1 || len(cs.runnerList) != 1 {
+		t.Errorf("lists that loaded were lost: users=%v runners=%v", cs.userList, cs.runnerList)
+	}
+
+	if cs.groupList != nil || cs.sourceList != nil {
+		t.Errorf("failed lists should stay empty: groups=%v sources=%v", cs.groupList, cs.sourceList)
+	}
+}
+
+func TestStateStopsWhenContextIsCanceled(t *testing.T) {
+	release := make(chan struct{})
+	srv, _ := newListServer(t, func(w http.ResponseWriter, _ *http.Request, _ int) {
+		<-release
+		_, _ = w.Write([]byte(`[]`))
+	})
+	t.Cleanup(func() { close(release) })
+	c := newTestClient(t, Config{Environment: srv.URL, StateCacheTTL: time.Minute})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.state(ctx, usersKind, agentsKind)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("state() error = %v, want %v", err, context.DeadlineExceeded)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("state() ignored the canceled context")
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	release := make(chan struct{})
+	srv, _ := newListServer(t, func(w http.ResponseWriter, _ *http.Request, _ int) {
+		<-release
+	})
+	t.Cleanup(func() { close(release) })
+
+	c := newTestClient(t, Config{Environment: srv.URL, RequestTimeout: 50 * time.Millisecond})
+
+	if _, err := c.ListUsers(context.Background()); err == nil {
+		t.Error("ListUsers() returned no error for a request exceeding the request timeout")
+	}
+}
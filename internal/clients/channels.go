@@ -0,0 +1,69 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+// slackChannel is a channel the org's Slack integration can post to.
+type slackChannel struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// channelDirectory lists the Slack channels of the org. The client looks them
+// up through the org's Slack integration, tests swap in a local stand-in.
+type channelDirectory interface {
+	slackChannels(ctx context.Context) ([]*slackChannel, error)
+}
+
+// integrationChannels is the channelDirectory backed by the api, it lists the
+// channels the Slack integration of the org can see. The endpoint is not part of
+// openapi.json, which only covers agents and runners, so TestIntegrationChannels
+// pins its path and the shape of its answer: a json array of {"id", "name"} objects.
+type integrationChannels struct {
+	c *Client
+}
+
+func (d integrationChannels) slackChannels(ctx context.Context) ([]*slackChannel, error) {
+	const (
+		path = "/api/v2/integrations/slack/channels"
+	)
+
+	resp, err := d.c.read(ctx, d.c.uri(path))
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*slackChannel
+	err = json.NewDecoder(resp).Decode(&result)
+
+	return result, err
+}
+
+// channelName returns a channel name without the # it may be written with.
+func channelName(s string) string {
+	return strings.TrimPrefix(strings.TrimSpace(s), "#")
+}
+
+// findChannel returns the channel with the given id or name, or nil.
+func findChannel(cs *state, key string) *slackChannel {
+	name := channelName(key)
+	for _, ch := range cs.channelList {
+		if ch.Id == key || equal(ch.Name, name) {
+			return ch
+		}
+	}
+
+	return nil
+}
+
+// resolveChannel returns the id of the channel with the given name.
+func resolveChannel(cs *state, name string) (string, error) {
+	if ch := findChannel(cs, name); ch != nil {
+		return ch.Id, nil
+	}
+
+	return "", eformat("slack channel \"%s\" was not found. check that the slack integration of the org can see it", name)
+}
@@ -0,0 +1,174 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-kubiya/internal/entities"
+)
+
+// localChannels stands in for the slack integration of the org.
+type localChannels struct {
+	list  []*slackChannel
+	calls int32
+}
+
+func (l *localChannels) slackChannels(context.Context) ([]*slackChannel, error) {
+	atomic.AddInt32(&l.calls, 1)
+	return l.list, nil
+}
+
+func newLocalChannels() *localChannels {
+	return &localChannels{list: []*slackChannel{
+		{Id: "C024BE91L", Name: "ops"},
+		{Id: "C0DEADBEEF", Name: "alerts"},
+	}}
+}
+
+func TestIntegrationChannels(t *testing.T) {
+	srv, ls := newListServer(t, func(w http.ResponseWriter, r *http.Request, _ int) {
+		if r.URL.Path != "/api/v2/integrations/slack/channels" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"id":"C024BE91L","name":"ops","is_private":false},{"id":"G0PRIVATE1","name":"oncall"}]`))
+	})
+	c := newTestClient(t, Config{Environment: srv.URL, StateCacheTTL: time.Minute})
+
+	cs, err := c.state(context.Background(), channelsKind)
+	if err != nil {
+		t.Fatalf("state() error = %v", err)
+	}
+
+	if n := ls.count("GET /api/v2/integrations/slack/channels"); n != 1 {
+		t.Errorf("the channels were listed %d times, want 1", n)
+	}
+
+	for name, want := range map[string]string{"#ops": "C024BE91L", "oncall": "G0PRIVATE1", "#OPS": "C024BE91L"} {
+		if id, err := resolveChannel(cs, name); err != nil || id != want {
+			t.Errorf("resolveChannel(%q) = %q, %v, want %q", name, id, err, want)
+		}
+	}
+
+	if _, err := resolveChannel(cs, "#random"); err == nil {
+		t.Error("resolveChannel() found a channel the integration does not list")
+	}
+}
+
+func TestScheduledTaskChannel(t *testing.T) {
+	var sent createScheduledTaskRequest
+	srv, _ := newListServer(t, func(w http.ResponseWriter, r *http.Request, _ int) {
+		switch {
+		case r.Method == http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&sent)
+			_, _ = w.Write([]byte(`{"task_id":"t1"}`))
+		case strings.HasSuffix(r.URL.Path, "/t1"):
+			// the api answers with the name only
+			_, _ = w.Write([]byte(`{"task_id":"t1","channel_name":"ops","parameters":{"context":"deployer"}}`))
+		default:
+			_, _ = w.Write([]byte(`{"task_id":"t2","channel_id":"C0DEADBEEF","parameters":{"context":"deployer"}}`))
+		}
+	})
+	c := newTestClient(t, Config{Environment: srv.URL, StateCacheTTL: time.Minute})
+	c.channels = newLocalChannels()
+
+	plan := &entities.ScheduledTaskModel{
+		Agent:         types.StringValue("deployer"),
+		Channel:       types.StringValue("#ops"),
+		ChannelId:     types.StringUnknown(),
+		Description:   types.StringValue("digest"),
+		Repeat:        types.StringValue("0 9 * * *"),
+		ScheduledTime: types.StringValue(""),
+		Parameters:    types.DynamicUnknown(),
+	}
+
+	result, err := c.CreateScheduledTask(context.Background(), plan)
+	if err != nil {
+		t.Fatalf("CreateScheduledTask() error = %v", err)
+	}
+
+	if sent.ChannelId != "C024BE91L" {
+		t.Errorf("sent channel id %q, want the id of #ops", sent.ChannelId)
+	}
+
+	if result.ChannelId.ValueString() != "C024BE91L" || result.Channel.ValueString() != "#ops" {
+		t.Errorf("channel = %s (%s), want the configured #ops (C024BE91L)", result.Channel, result.ChannelId)
+	}
+
+	// a read without prior state, e.g. an import, fills the name in
+	imported, err := c.ReadScheduledTask(context.Background(), "t2", nil)
+	if err != nil {
+		t.Fatalf("ReadScheduledTask() error = %v", err)
+	}
+
+	if imported.ChannelId.ValueString() != "C0DEADBEEF" || imported.Channel.ValueString() != "alerts" {
+		t.Errorf("channel = %s (%s), want alerts (C0DEADBEEF)", imported.Channel, imported.ChannelId)
+	}
+
+	plan.Channel = types.StringValue("#missing")
+	if _, err := c.CreateScheduledTask(context.Background(), plan); err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Errorf("CreateScheduledTask() error = %v, want the unknown channel reported", err)
+	}
+}
+
+func TestWebhookSlackChannel(t *testing.T) {
+	var sent webhook
+	srv, _ := newListServer(t, func(w http.ResponseWriter, r *http.Request, _ int) {
+		switch {
+		case r.URL.Path == "/api/v1/agents":
+			_, _ = w.Write([]byte(`[]`))
+		case r.Method == http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&sent)
+			_ = json.NewEncoder(w).Encode(sent)
+		default:
+			_, _ = w.Write([]byte(`{"id":"w1","name":"deploys","communication":{"method":"Slack","destination":"#C024BE91L"}}`))
+		}
+	})
+	c := newTestClient(t, Config{Environment: srv.URL, StateCacheTTL: time.Minute})
+	channels := newLocalChannels()
+	c.channels = channels
+
+	plan := &entities.WebhookModel{
+		Name:        types.StringValue("deploys"),
+		Prompt:      types.StringValue("summarize"),
+		Workflow:    types.StringValue(`{"name":"deploy"}`),
+		Method:      types.StringValue("Slack"),
+		Destination: types.StringValue("#ops"),
+	}
+
+	result, err := c.CreateWebhook(context.Background(), plan)
+	if err != nil {
+		t.Fatalf("CreateWebhook() error = %v", err)
+	}
+
+	if sent.Communication == nil || sent.Communication.Destination != "#C024BE91L" {
+		t.Errorf("sent communication %+v, want the id of #ops", sent.Communication)
+	}
+
+	if result.Destination.ValueString() != "#ops" {
+		t.Errorf("destination = %s, want #ops", result.Destination)
+	}
+
+	// a channel configured by id is neither looked up nor renamed
+	before := atomic.LoadInt32(&channels.calls)
+	byId := &entities.WebhookModel{Id: types.StringValue("w1"), Destination: types.StringValue("#C024BE91L")}
+	if err := c.ReadWebhook(context.Background(), byId); err != nil {
+		t.Fatalf("ReadWebhook() error = %v", err)
+	}
+
+	if byId.Destination.ValueString() != "#C024BE91L" || atomic.LoadInt32(&channels.calls) != before {
+		t.Errorf("destination = %s after %d lookups, want the configured id", byId.Destination, atomic.LoadInt32(&channels.calls)-before)
+	}
+
+	plan.Destination = types.StringValue("#missing")
+	if _, err := c.CreateWebhook(context.Background(), plan); err == nil {
+		t.Error("CreateWebhook() accepted a channel the slack integration does not know")
+	}
+}
@@ -7,42 +7,178 @@ import (
 	"errors"
 	"net/http"
 	"strings"
-
-	"terraform-provider-kubiya/internal/clients/vendors"
+	"sync"
+	"time"
 )
 
+// DefaultRequestTimeout is how long a single http attempt may take unless configured otherwise.
+const DefaultRequestTimeout = 2 * time.Minute
+
+type Config struct {
+	ApiKey      string
+	ApiUrl      string
+	Environment string
+	ComposerUrl string
+
+	// Auth selects how the api calls authenticate. The zero value sends ApiKey.
+	Auth AuthConfig
+
+	// MaxRetries is the number of times a failed request is retried. Zero disables retries.
+	MaxRetries   int
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+
+	// StateCacheTTL is how long org wide lists are reused between calls. Zero disables caching.
+	StateCacheTTL time.Duration
+
+	// ProviderVersion, TerraformVersion and UserAgentSuffix make up the User-Agent header.
+	ProviderVersion  string
+	TerraformVersion string
+	UserAgentSuffix  string
+
+	// RequestTimeout bounds each http attempt, a retried request starts a fresh timeout. Zero means no limit.
+	RequestTimeout time.Duration
+
+	// CACertFile or CACertPEM is a pem bundle trusted on top of the system roots.
+	CACertFile string
+	CACertPEM  string
+
+	// ClientCert and ClientKey are the pem encoded certificate and key presented for mutual tls.
+	ClientCert string
+	ClientKey  string
+
+	InsecureSkipVerify bool
+
+	// ProxyUrl overrides the HTTPS_PROXY and NO_PROXY environment variables.
+	ProxyUrl string
+
+	// RateLimit is the number of http attempts per second, RateBurst how many may
+	// start at once. MaxInFlight bounds the attempts running at the same time.
+	// Zero disables each limit.
+	RateLimit   float64
+	RateBurst   int
+	MaxInFlight int
+}
+
 type Client struct {
-	host    string
-	userKey string
-	client  *http.Client
+	host      string
+	composer  string
+	userKey   string
+	userAgent string
+	creds     credentials
+	channels  channelDirectory
+	client    *http.Client
+	retry     retryPolicy
+	cache     *stateCache
 }
 
-func New(key, env string) (*Client, error) {
-	if len(key) == 0 {
-		return nil, eformat("ApiKey is missing or empty")
-	}
-	client := &http.Client{}
-	host := ""
-	switch env {
-	case "production":
+func New(cfg Config) (*Client, error) {
+	const productionComposer = "https://composer.kubiya.ai"
+
+	host, composer := "", ""
+	switch cfg.Environment {
+	case "", "production":
 		host = "https://api.kubiya.ai"
+		composer = productionComposer
 	case "staging":
 		host = "https://api-staging.dev.kubiya.ai"
+		composer = "https://composer-staging.dev.kubiya.ai"
+	}
+	if strings.HasPrefix(cfg.Environment, "http") {
+		// self-hosted and local environments serve the composer api next to the main api
+		host = cfg.Environment
+		composer = cfg.Environment
+	}
+	if len(cfg.ApiUrl) > 0 {
+		host = cfg.ApiUrl
+		// a custom api host never talks to the production composer unless asked to
+		if composer == productionComposer {
+			composer = ""
+		}
+	}
+	if len(cfg.ComposerUrl) > 0 {
+		composer = cfg.ComposerUrl
+	}
+	if len(host) == 0 {
+		return nil, eformat("environment \"%s\" is not valid. use production, staging or a base url", cfg.Environment)
+	}
+	retry := retryPolicy{
+		maxRetries: cfg.MaxRetries,
+		waitMin:    cfg.RetryWaitMin,
+		waitMax:    cfg.RetryWaitMax,
+	}
+	if cfg.RequestTimeout < 0 {
+		return nil, eformat("request timeout must not be negative")
+	}
+	if cfg.RateLimit < 0 || cfg.RateBurst < 0 || cfg.MaxInFlight < 0 {
+		return nil, eformat("rate limit, rate burst and max in flight must not be negative")
+	}
+	client, err := newHTTPClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if retry.maxRetries < 0 {
+		return nil, eformat("max retries must not be negative")
+	}
+	if retry.waitMin <= 0 {
+		retry.waitMin = defaultRetryWaitMin
+	}
+	if retry.waitMax <= 0 {
+		retry.waitMax = defaultRetryWaitMax
+	}
+	if retry.waitMin > retry.waitMax {
+		return nil, eformat("retry wait min (%s) must not exceed retry wait max (%s)", retry.waitMin, retry.waitMax)
+	}
+	agent := userAgent(cfg)
+	creds, err := newCredentials(cfg, client, agent)
+	if err != nil {
+		return nil, err
+	}
+	result := &Client{
+		host:      strings.TrimSuffix(host, "/"),
+		composer:  strings.TrimSuffix(composer, "/"),
+		userKey:   cfg.ApiKey,
+		userAgent: agent,
+		creds:     creds,
+		client:    client,
+		retry:     retry,
+		cache:     newStateCache(cfg.StateCacheTTL),
+	}
+	result.channels = integrationChannels{c: result}
+	return result, nil
+}
+
+// userAgent returns e.g. "terraform-provider-kubiya/1.2.0 terraform/1.9.5 ci-pipeline/42".
+func userAgent(cfg Config) string {
+	const (
+		product = "terraform-provider-kubiya"
+		unknown = "dev"
+	)
+
+	version := cfg.ProviderVersion
+	if len(version) == 0 {
+		version = unknown
+	}
+
+	parts := []string{format("%s/%s", product, version)}
+
+	if len(cfg.TerraformVersion) >= 1 {
+		parts = append(parts, format("terraform/%s", cfg.TerraformVersion))
 	}
-	if strings.HasPrefix(env, "http") {
-		host = env
+
+	if suffix := strings.TrimSpace(cfg.UserAgentSuffix); len(suffix) >= 1 {
+		parts = append(parts, suffix)
 	}
-	return &Client{userKey: key, client: client, host: host}, nil
 
+	return strings.Join(parts, " ")
 }
 
-func (c *Client) self() (*user, error) {
+func (c *Client) self(ctx context.Context) (*user, error) {
 	const (
 		path = "/api/v1/users/self"
 	)
 
 	uri := c.uri(path)
-	ctx := context.Background()
 
 	resp, err := c.read(ctx, uri)
 	if err != nil {
@@ -55,86 +191,107 @@ func (c *Client) self() (*user, error) {
 	return result, err
 }
 
-func (c *Client) state() (*state, error) {
-	var err error
-	var currentState state
-
-	if users, e := c.users(); e != nil {
-		err = errors.Join(err, e)
-	} else {
-		currentState.userList = append(make([]*user, 0), users...)
-	}
-
-	if agents, e := c.agents(); e != nil {
-		err = errors.Join(err, e)
-	} else {
-		currentState.agentList = append(make([]*agent, 0), agents...)
-	}
-
-	if groups, e := c.groups(); e != nil {
-		err = errors.Join(err, e)
-	} else {
-		currentState.groupList = append(make([]*group, 0), groups...)
-	}
-
-	if models, e := c.models(); e != nil {
-		err = errors.Join(err, e)
-	} else {
-		currentState.modelList = append(make([]string, 0), models...)
-	}
-
-	if runners, e := c.runners(); e != nil {
-		err = errors.Join(err, e)
-	} else {
-		currentState.runnerList = append(make([]*runner, 0), runners...)
-	}
-
-	if secrets, e := c.secrets(); e != nil {
-		err = errors.Join(err, e)
-	} else {
-		currentState.secretList = append(make([]*secret, 0), secrets...)
-	}
+// state returns a snapshot of the requested org wide lists, fetched concurrently
+// and served from the provider cache when possible. Lists that are not requested stay empty.
+func (c *Client) state(ctx context.Context, kinds ...stateKind) (*state, error) {
+	var (
+		err          error
+		mu           sync.Mutex
+		wg           sync.WaitGroup
+		currentState state
+	)
 
-	if sources, e := c.sources(); e != nil {
-		err = errors.Join(err, e)
-	} else {
-		currentState.sourceList = append(make([]*source, 0), sources...)
+	seen := make(map[stateKind]bool)
+	for _, kind := range kinds {
+		if seen[kind] {
+			continue
+		}
+		seen[kind] = true
+
+		wg.Add(1)
+		go func(kind stateKind) {
+			defer wg.Done()
+
+			if e := c.load(ctx, kind, &currentState, &mu); e != nil {
+				mu.Lock()
+				err = errors.Join(err, e)
+				mu.Unlock()
+			}
+		}(kind)
 	}
 
-	if webhooks, e := c.webhooks(); e != nil {
-		err = errors.Join(err, e)
-	} else {
-		currentState.webhookList = append(make([]*webhook, 0), webhooks...)
-	}
+	wg.Wait()
 
-	if integrations, e := c.integrations(); e != nil {
-		err = errors.Join(err, e)
-	} else {
-		currentState.integrationList = append(make([]*integration, 0), integrations...)
-	}
+	return &currentState, err
+}
 
-	if knowledgeList, e := c.knowledge(); e != nil {
-		err = errors.Join(err, e)
-	} else {
-		currentState.knowledgeList = append(make([]*knowledge, 0), knowledgeList...)
+func (c *Client) load(ctx context.Context, kind stateKind, cs *state, mu *sync.Mutex) error {
+	lock := func(f func()) {
+		mu.Lock()
+		defer mu.Unlock()
+		f()
 	}
 
-	if externalKnowledgeList, e := c.externalKnowledge(); e != nil {
-		err = errors.Join(err, e)
-	} else {
-		currentState.externalKnowledgeList = append(make([]*vendors.BaseExternalKnowledge, 0), externalKnowledgeList...)
+	switch kind {
+	case usersKind:
+		users, err := cached(ctx, c.cache, kind, c.users)
+		lock(func() { cs.userList = users })
+		return err
+	case agentsKind:
+		agents, err := cached(ctx, c.cache, kind, c.agents)
+		lock(func() { cs.agentList = agents })
+		return err
+	case groupsKind:
+		groups, err := cached(ctx, c.cache, kind, c.groups)
+		lock(func() { cs.groupList = groups })
+		return err
+	case modelsKind:
+		models, err := cached(ctx, c.cache, kind, c.models)
+		lock(func() { cs.modelList = models })
+		return err
+	case runnersKind:
+		runners, err := cached(ctx, c.cache, kind, c.runners)
+		lock(func() { cs.runnerList = runners })
+		return err
+	case secretsKind:
+		secrets, err := cached(ctx, c.cache, kind, c.secrets)
+		lock(func() { cs.secretList = secrets })
+		return err
+	case sourcesKind:
+		sources, err := cached(ctx, c.cache, kind, c.sources)
+		lock(func() { cs.sourceList = sources })
+		return err
+	case webhooksKind:
+		webhooks, err := cached(ctx, c.cache, kind, c.webhooks)
+		lock(func() { cs.webhookList = webhooks })
+		return err
+	case integrationsKind:
+		integrations, err := cached(ctx, c.cache, kind, c.integrations)
+		lock(func() { cs.integrationList = integrations })
+		return err
+	case knowledgeKind:
+		knowledgeList, err := cached(ctx, c.cache, kind, c.knowledge)
+		lock(func() { cs.knowledgeList = knowledgeList })
+		return err
+	case externalKnowledgeKind:
+		externalKnowledgeList, err := cached(ctx, c.cache, kind, c.externalKnowledge)
+		lock(func() { cs.externalKnowledgeList = externalKnowledgeList })
+		return err
+	case channelsKind:
+		channels, err := cached(ctx, c.cache, kind, c.channels.slackChannels)
+		lock(func() { cs.channelList = channels })
+		return err
 	}
 
-	return &currentState, err
+	return eformat("unknown state kind %d", kind)
 }
 
-func (c *Client) users() ([]*user, error) {
+func (c *Client) users(ctx context.Context) ([]*user, error) {
 	const (
 		path = "/api/v1/users"
 	)
 
 	uri := c.uri(path)
-	ctx := context.Background()
 
 	resp, err := c.read(ctx, uri)
 	if err != nil {
@@ -147,13 +304,12 @@ func (c *Client) users() ([]*user, error) {
 	return result, err
 }
 
-func (c *Client) agents() ([]*agent, error) {
+func (c *Client) agents(ctx context.Context) ([]*agent, error) {
 	const (
 		path = "/api/v1/agents"
 	)
 
 	uri := c.uri(path)
-	ctx := context.Background()
 
 	resp, err := c.read(ctx, uri)
 	if err != nil {
@@ -166,13 +322,12 @@ func (c *Client) agents() ([]*agent, error) {
 	return result, err
 }
 
-func (c *Client) groups() ([]*group, error) {
+func (c *Client) groups(ctx context.Context) ([]*group, error) {
 	const (
 		path = "/api/v1/manage/groups"
 	)
 
 	uri := c.uri(path)
-	ctx := context.Background()
 
 	resp, err := c.readBytes(ctx, uri)
 	if err != nil {
@@ -185,7 +340,7 @@ func (c *Client) groups() ([]*group, error) {
 	return result, err
 }
 
-func (c *Client) models() ([]string, error) {
+func (c *Client) models(ctx context.Context) ([]string, error) {
 	const (
 		sep  = ","
 		path = "/api/v1/featureflags"
@@ -193,7 +348,7 @@ func (c *Client) models() ([]string, error) {
 	)
 
 	uri := c.uri(path)
-	ctx := context.Background()
+	ctx = withIdempotent(ctx)
 	payload := strings.NewReader(body)
 
 	resp, err := c.create(ctx, uri, payload)
@@ -212,19 +367,20 @@ func (c *Client) models() ([]string, error) {
 	var result []string
 
 	for _, item := range strings.Split(tmp.Models, sep) {
-		result = append(result, strings.TrimSpace(item))
+		if item = strings.TrimSpace(item); len(item) >= 1 {
+			result = append(result, item)
+		}
 	}
 
 	return result, err
 }
 
-func (c *Client) runners() ([]*runner, error) {
+func (c *Client) runners(ctx context.Context) ([]*runner, error) {
 	const (
 		path = "/api/v3/runners"
 	)
 
 	uri := c.uri(path)
-	ctx := context.Background()
 
 	resp, err := c.read(ctx, uri)
 	if err != nil {
@@ -237,13 +393,12 @@ func (c *Client) runners() ([]*runner, error) {
 	return result, err
 }
 
-func (c *Client) secrets() ([]*secret, error) {
+func (c *Client) secrets(ctx context.Context) ([]*secret, error) {
 	const (
 		path = "/api/v2/secrets"
 	)
 
 	uri := c.uri(path)
-	ctx := context.Background()
 
 	resp, err := c.read(ctx, uri)
 	if err != nil {
@@ -256,13 +411,12 @@ func (c *Client) secrets() ([]*secret, error) {
 	return result, err
 }
 
-func (c *Client) sources() ([]*source, error) {
+func (c *Client) sources(ctx context.Context) ([]*source, error) {
 	const (
 		path = "/api/v1/sources"
 	)
 
 	uri := c.uri(path)
-	ctx := context.Background()
 
 	resp, err := c.read(ctx, uri)
 	if err != nil {
@@ -272,13 +426,12 @@ func (c *Client) sources() ([]*source, error) {
 	return newSources(resp)
 }
 
-func (c *Client) webhooks() ([]*webhook, error) {
+func (c *Client) webhooks(ctx context.Context) ([]*webhook, error) {
 	const (
 		path = "/api/v1/event"
 	)
 
 	uri := c.uri(path)
-	ctx := context.Background()
 
 	resp, err := c.read(ctx, uri)
 	if err != nil {
@@ -291,13 +444,12 @@ func (c *Client) webhooks() ([]*webhook, error) {
 	return result, err
 }
 
-func (c *Client) knowledge() ([]*knowledge, error) {
+func (c *Client) knowledge(ctx context.Context) ([]*knowledge, error) {
 	const (
 		path = "/api/v1/knowledge"
 	)
 
 	uri := c.uri(path)
-	ctx := context.Background()
 
 	resp, err := c.read(ctx, uri)
 	if err != nil {
@@ -310,12 +462,11 @@ func (c *Client) knowledge() ([]*knowledge, error) {
 	return result, err
 }
 
-func (c *Client) integrations() ([]*integration, error) {
+func (c *Client) integrations(ctx context.Context) ([]*integration, error) {
 	const (
 		pathIntegration = "/api/v2/integrations"
 	)
 
-	ctx := context.Background()
 	result := []*integration{
 		{Name: "slack"},
 		{Name: "kubernetes"},
@@ -6,8 +6,10 @@ import (
 	"errors"
 	"io"
 	"net/http"
-	"os"
 	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 func (c *Client) uri(path string) string {
@@ -23,24 +25,14 @@ func (c *Client) uri(path string) string {
 	return format(layout, c.host, path)
 }
 
-func (c *Client) downloadFile(uri, path string) error {
-	ctx := context.Background()
-	resp, err := c.read(ctx, uri)
-	if err != nil {
-		return err
-	}
-
-	file, err := os.Create(path)
-	if err != nil {
-		return err
-	}
-
-	_, err = io.Copy(file, resp)
+// download returns the body of uri as text, e.g. a yaml file served by the api.
+func (c *Client) download(ctx context.Context, uri string) (string, error) {
+	body, err := c.makeRequestWithBytes(ctx, http.MethodGet, uri, nil, nil)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	return nil
+	return string(body), nil
 }
 
 func (c *Client) uriWithHost(host, path string) string {
@@ -60,48 +52,85 @@ func (c *Client) uriWithHost(host, path string) string {
 	return format(layout, host, path)
 }
 
-func (c *Client) auth(req *http.Request) *http.Request {
+// composerUri returns the composer url for path. Only production has a known
+// composer host, every other environment must set composer_url explicitly.
+func (c *Client) composerUri(path string) (string, error) {
+	if len(c.composer) == 0 {
+		return "", eformat("the composer url is not known for %s. set composer_url or KUBIYA_COMPOSER_URL", c.host)
+	}
+
+	return c.uriWithHost(c.composer, path), nil
+}
+
+func (c *Client) auth(ctx context.Context, req *http.Request) error {
 	const (
-		authLayout = "UserKey %s"
-		authHeader = "Authorization"
-		source     = "source"
-		terraform  = "terraform"
+		authHeader      = "Authorization"
+		userAgentHeader = "User-Agent"
+		source          = "source"
+		terraform       = "terraform"
 	)
 
-	if req != nil {
-		req.Header.Set(authHeader, format(authLayout, c.userKey))
-		req.Header.Set(source, terraform)
+	authorization, err := c.creds.authorization(ctx)
+	if err != nil {
+		return err
 	}
 
-	return req
+	req.Header.Set(authHeader, authorization)
+	req.Header.Set(userAgentHeader, c.userAgent)
+	req.Header.Set(source, terraform)
+
+	return nil
 }
 
 func (c *Client) do(req *http.Request) (*http.Response, error) {
 	if req != nil {
-		req = c.auth(req)
-		resp, err := c.client.Do(req)
-		if err != nil || resp == nil {
-			if err != nil {
-				return nil, err
-			}
+		if err := rewindable(req); err != nil {
+			return nil, err
+		}
 
-			return nil, eformat("failed to make http request. *http.Response is nil")
+		// the transport logs through the request context, e.g. when it throttles the call
+		ctx := c.logContext(req.Context())
+		req = req.WithContext(ctx)
+
+		if err := c.auth(ctx, req); err != nil {
+			return nil, err
 		}
 
-		if resp.StatusCode >= http.StatusBadRequest {
-			defer closeBody(resp.Body)
+		logRequest(ctx, req)
+
+		start := time.Now()
+		resp, err := c.send(ctx, req)
+		if err == nil && resp != nil && resp.StatusCode == http.StatusUnauthorized && c.creds.reject() {
+			// a token that expired early or was revoked is replaced once
+			tflog.SubsystemDebug(ctx, logSubsystem, "Refreshing the rejected API credentials", map[string]any{
+				"method": req.Method,
+				"url":    req.URL.String(),
+			})
 
-			b, e := io.ReadAll(resp.Body)
-			if e != nil {
-				err = errors.Join(err, e)
+			_, _ = io.Copy(io.Discard, resp.Body)
+			closeBody(resp.Body)
+
+			resp, err = c.resend(ctx, req)
+		}
+
+		if err != nil || resp == nil {
+			if err == nil {
+				err = eformat("failed to make http request. *http.Response is nil")
 			}
 
-			response := string(b)
-			statusCode := resp.StatusCode
-			requestUrl := req.URL.String()
+			logResponse(ctx, req, nil, nil, err, start)
+			return nil, err
+		}
+
+		// the body is buffered so it can be logged, every caller reads it whole anyway
+		b, err := io.ReadAll(resp.Body)
+		closeBody(resp.Body)
+		resp.Body = io.NopCloser(bytes.NewReader(b))
 
-			err = errors.Join(err, eformat("request :%s has failed. status code: %d, response: %s",
-				requestUrl, statusCode, response))
+		logResponse(ctx, req, resp, b, err, start)
+
+		if resp.StatusCode >= http.StatusBadRequest {
+			err = errors.Join(err, newAPIError(req, resp, b))
 		}
 
 		return resp, err
@@ -110,6 +139,66 @@ func (c *Client) do(req *http.Request) (*http.Response, error) {
 	return nil, eformat("req of type: *http.Request is nil")
 }
 
+// resend sends req again with fresh credentials.
+func (c *Client) resend(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if err := c.auth(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = body
+	}
+
+	return c.send(ctx, req)
+}
+
+// send executes req, retrying transient failures according to the client retry policy.
+// ctx only carries the loggers, req has its own context.
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := c.client.Do(req)
+		if attempt >= c.retry.maxRetries || !c.retry.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := c.retry.backoff(attempt, resp)
+
+		fields := map[string]any{
+			"method":  req.Method,
+			"url":     req.URL.String(),
+			"attempt": attempt + 1,
+			"wait_ms": wait.Milliseconds(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["status"] = resp.StatusCode
+		}
+		tflog.SubsystemDebug(ctx, logSubsystem, "Retrying API request", fields)
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			closeBody(resp.Body)
+		}
+
+		if e := sleep(req.Context(), wait); e != nil {
+			return nil, errors.Join(err, e)
+		}
+	}
+}
+
 func (c *Client) doWithBody(req *http.Request) ([]byte, error) {
 	r, err := c.do(req)
 	if err != nil {
@@ -122,7 +211,7 @@ func (c *Client) doWithBody(req *http.Request) ([]byte, error) {
 
 // createRequest is a helper function to create and configure HTTP requests
 func (c *Client) createRequest(ctx context.Context, method, url string, body io.Reader, headers map[string]string, qp ...string) (*http.Request, error) {
-	req, err := http.NewRequest(method, url, body)
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil || req == nil {
 		if err != nil {
 			return nil, err
@@ -139,7 +228,7 @@ func (c *Client) createRequest(ctx context.Context, method, url string, body io.
 		req.URL.RawQuery = strings.Join(qp, "&")
 	}
 
-	return req.WithContext(ctx), nil
+	return req, nil
 }
 
 // makeRequestWithResponse returns the raw HTTP response
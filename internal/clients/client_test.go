@@ -0,0 +1,91 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUserAgent(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{
+			name: "versions",
+			cfg:  Config{ProviderVersion: "1.2.0", TerraformVersion: "1.9.5"},
+			want: "terraform-provider-kubiya/1.2.0 terraform/1.9.5",
+		},
+		{
+			name: "suffix",
+			cfg:  Config{ProviderVersion: "1.2.0", TerraformVersion: "1.9.5", UserAgentSuffix: " ci-pipeline/42 "},
+			want: "terraform-provider-kubiya/1.2.0 terraform/1.9.5 ci-pipeline/42",
+		},
+		{
+			name: "unknown versions",
+			cfg:  Config{},
+			want: "terraform-provider-kubiya/dev",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get("User-Agent")
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer srv.Close()
+
+			cfg := tt.cfg
+			cfg.ApiKey = "test-key"
+			cfg.Environment = srv.URL
+
+			c, err := New(cfg)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			if _, err := c.read(context.Background(), c.uri("/api/v1/users/self")); err != nil {
+				t.Fatalf("read() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("User-Agent = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComposerDefaults(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{name: "production", cfg: Config{Environment: "production"}, want: "https://composer.kubiya.ai"},
+		{name: "staging", cfg: Config{Environment: "staging"}, want: "https://composer-staging.dev.kubiya.ai"},
+		{name: "self-hosted", cfg: Config{Environment: "https://kubiya.example.com"}, want: "https://kubiya.example.com"},
+		{name: "custom api host", cfg: Config{ApiUrl: "https://api.example.com"}, want: ""},
+		{name: "staging with a custom api host", cfg: Config{Environment: "staging", ApiUrl: "https://api.example.com"}, want: "https://composer-staging.dev.kubiya.ai"},
+		{name: "composer url", cfg: Config{ApiUrl: "https://api.example.com", ComposerUrl: "https://composer.example.com"}, want: "https://composer.example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			cfg.ApiKey = "test-key"
+
+			c, err := New(cfg)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			if c.composer != tt.want {
+				t.Errorf("composer = %q, want %q", c.composer, tt.want)
+			}
+		})
+	}
+}
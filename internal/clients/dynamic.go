@@ -0,0 +1,227 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// fromDynamic returns the json value of a terraform value: strings, booleans,
+// numbers, objects, maps and lists in any nesting. Null becomes nil.
+func fromDynamic(value attr.Value) (any, error) {
+	if value == nil || value.IsNull() {
+		return nil, nil
+	}
+
+	if value.IsUnknown() {
+		return nil, eformat("the value is not known until apply")
+	}
+
+	switch v := value.(type) {
+	case types.Dynamic:
+		return fromDynamic(v.UnderlyingValue())
+	case types.String:
+		return v.ValueString(), nil
+	case types.Bool:
+		return v.ValueBool(), nil
+	case types.Number:
+		return json.Number(v.ValueBigFloat().Text('g', -1)), nil
+	case types.Int64:
+		return v.ValueInt64(), nil
+	case types.Float64:
+		return v.ValueFloat64(), nil
+	case types.Object:
+		return fromDynamicMap(v.Attributes())
+	case types.Map:
+		return fromDynamicMap(v.Elements())
+	case types.List:
+		return fromDynamicList(v.Elements())
+	case types.Set:
+		return fromDynamicList(v.Elements())
+	case types.Tuple:
+		return fromDynamicList(v.Elements())
+	}
+
+	return nil, eformat("values of type %s are not supported", value.Type(context.Background()))
+}
+
+func fromDynamicMap(elements map[string]attr.Value) (map[string]any, error) {
+	result := make(map[string]any, len(elements))
+	for key, element := range elements {
+		v, err := fromDynamic(element)
+		if err != nil {
+			return nil, eformat("%s: %w", key, err)
+		}
+		result[key] = v
+	}
+
+	return result, nil
+}
+
+func fromDynamicList(elements []attr.Value) ([]any, error) {
+	result := make([]any, 0, len(elements))
+	for i, element := range elements {
+		v, err := fromDynamic(element)
+		if err != nil {
+			return nil, eformat("[%d]: %w", i, err)
+		}
+		result = append(result, v)
+	}
+
+	return result, nil
+}
+
+// toDynamic returns the terraform value of a json value decoded with UseNumber.
+// like is the type the value had in the configuration, the result takes its
+// shape so a map stays a map and a list a list. Without it the type is inferred
+// from the value, objects become objects and arrays tuples.
+func toDynamic(value any, like attr.Type) (attr.Value, error) {
+	ctx := context.Background()
+
+	if _, ok := like.(basetypes.DynamicType); ok {
+		like = nil
+	}
+
+	if value == nil {
+		if like == nil {
+			return types.StringNull(), nil
+		}
+		return like.ValueFromTerraform(ctx, tftypes.NewValue(like.TerraformType(ctx), nil))
+	}
+
+	switch t := like.(type) {
+	case basetypes.StringType:
+		if s, ok := value.(string); ok {
+			return types.StringValue(s), nil
+		}
+		return types.StringValue(fmt.Sprint(value)), nil
+	case basetypes.MapType:
+		if m, ok := value.(map[string]any); ok {
+			elements := make(map[string]attr.Value, len(m))
+			for key, item := range m {
+				element, err := toDynamic(item, t.ElemType)
+				if err != nil {
+					return nil, err
+				}
+				elements[key] = element
+			}
+			return valueOrError(types.MapValue(t.ElemType, elements))
+		}
+	case basetypes.ObjectType:
+		if m, ok := value.(map[string]any); ok {
+			return toDynamicObject(m, t.AttrTypes)
+		}
+	case basetypes.ListType:
+		if l, ok := value.([]any); ok {
+			elements, err := toDynamicElements(l, func(int) attr.Type { return t.ElemType })
+			if err != nil {
+				return nil, err
+			}
+			return valueOrError(types.ListValue(t.ElemType, elements))
+		}
+	case basetypes.SetType:
+		if l, ok := value.([]any); ok {
+			elements, err := toDynamicElements(l, func(int) attr.Type { return t.ElemType })
+			if err != nil {
+				return nil, err
+			}
+			return valueOrError(types.SetValue(t.ElemType, elements))
+		}
+	case basetypes.TupleType:
+		if l, ok := value.([]any); ok {
+			return toDynamicTuple(l, func(i int) attr.Type {
+				if i < len(t.ElemTypes) {
+					return t.ElemTypes[i]
+				}
+				return nil
+			})
+		}
+	}
+
+	// the api changed the shape of the value, or there is no configured type to follow
+	switch v := value.(type) {
+	case string:
+		return types.StringValue(v), nil
+	case bool:
+		return types.BoolValue(v), nil
+	case json.Number:
+		f, _, err := big.ParseFloat(v.String(), 10, 512, big.ToNearestEven)
+		if err != nil {
+			return nil, err
+		}
+		return types.NumberValue(f), nil
+	case float64:
+		return types.NumberValue(big.NewFloat(v)), nil
+	case map[string]any:
+		return toDynamicObject(v, nil)
+	case []any:
+		return toDynamicTuple(v, func(int) attr.Type { return nil })
+	}
+
+	return nil, eformat("values of type %T are not supported", value)
+}
+
+func toDynamicObject(m map[string]any, attrTypes map[string]attr.Type) (attr.Value, error) {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	objectTypes := make(map[string]attr.Type, len(m))
+	values := make(map[string]attr.Value, len(m))
+	for _, key := range keys {
+		v, err := toDynamic(m[key], attrTypes[key])
+		if err != nil {
+			return nil, eformat("%s: %w", key, err)
+		}
+		values[key] = v
+		objectTypes[key] = v.Type(context.Background())
+	}
+
+	return valueOrError(types.ObjectValue(objectTypes, values))
+}
+
+func toDynamicTuple(l []any, elemType func(int) attr.Type) (attr.Value, error) {
+	elements, err := toDynamicElements(l, elemType)
+	if err != nil {
+		return nil, err
+	}
+
+	elemTypes := make([]attr.Type, 0, len(elements))
+	for _, element := range elements {
+		elemTypes = append(elemTypes, element.Type(context.Background()))
+	}
+
+	return valueOrError(types.TupleValue(elemTypes, elements))
+}
+
+func toDynamicElements(l []any, elemType func(int) attr.Type) ([]attr.Value, error) {
+	result := make([]attr.Value, 0, len(l))
+	for i, item := range l {
+		element, err := toDynamic(item, elemType(i))
+		if err != nil {
+			return nil, eformat("[%d]: %w", i, err)
+		}
+		result = append(result, element)
+	}
+
+	return result, nil
+}
+
+// valueOrError turns the diagnostics of a value constructor into an error.
+func valueOrError[T attr.Value](value T, diags diag.Diagnostics) (attr.Value, error) {
+	if diags.HasError() {
+		return nil, diagnosticsToErrors(diags)
+	}
+
+	return value, nil
+}
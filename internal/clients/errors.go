@@ -0,0 +1,79 @@
+package clients
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// ErrNotFound is wrapped by the errors returned when a lookup matches nothing,
+// e.g. an object missing from a list the api only exposes in bulk.
+var ErrNotFound = errors.New("not found")
+
+// requestIdHeaders are the response headers the api and its gateways report the request id in.
+var requestIdHeaders = []string{"X-Request-Id", "X-Correlation-Id", "X-Amzn-Requestid"}
+
+// APIError is returned for every response with a status code of 400 or above.
+type APIError struct {
+	Method     string
+	Url        string
+	StatusCode int
+	RequestId  string
+
+	// Raw is the response body as received, Body is the same body decoded
+	// from json, or nil when the api did not answer with json.
+	Raw  string
+	Body any
+}
+
+func newAPIError(req *http.Request, resp *http.Response, body []byte) *APIError {
+	result := &APIError{
+		Method:     req.Method,
+		Url:        req.URL.String(),
+		StatusCode: resp.StatusCode,
+		RequestId:  requestId(resp.Header),
+		Raw:        string(body),
+	}
+
+	if err := json.Unmarshal(body, &result.Body); err != nil {
+		result.Body = nil
+	}
+
+	return result
+}
+
+// requestId returns the id the api assigned to the request, if any.
+func requestId(h http.Header) string {
+	for _, header := range requestIdHeaders {
+		if id := h.Get(header); len(id) >= 1 {
+			return id
+		}
+	}
+
+	return ""
+}
+
+func (e *APIError) Error() string {
+	if len(e.RequestId) >= 1 {
+		return format("request %s %s has failed. status code: %d, request id: %s, response: %s",
+			e.Method, e.Url, e.StatusCode, e.RequestId, e.Raw)
+	}
+
+	return format("request %s %s has failed. status code: %d, response: %s",
+		e.Method, e.Url, e.StatusCode, e.Raw)
+}
+
+// Unwrap lets errors.Is(err, ErrNotFound) hold for a 404 response.
+func (e *APIError) Unwrap() error {
+	if e.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// IsNotFound reports whether err means the object no longer exists, either
+// because the api answered 404 or because a lookup matched nothing.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
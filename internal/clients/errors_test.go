@@ -0,0 +1,52 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestAPIError(t *testing.T) {
+	srv, _ := newListServer(t, func(w http.ResponseWriter, r *http.Request, _ int) {
+		w.Header().Set("X-Request-Id", "req-1")
+		switch r.URL.Path {
+		case "/api/v1/sources/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"detail":"source not found"}`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`forbidden`))
+		}
+	})
+	c := newTestClient(t, Config{Environment: srv.URL, StateCacheTTL: time.Minute})
+
+	_, err := c.ReadSource(context.Background(), "missing")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("ReadSource() error = %v, want an *APIError", err)
+	}
+
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.RequestId != "req-1" {
+		t.Errorf("APIError = %+v, want status 404 and request id req-1", apiErr)
+	}
+
+	if body, ok := apiErr.Body.(map[string]any); !ok || body["detail"] != "source not found" {
+		t.Errorf("APIError.Body = %#v, want the decoded json body", apiErr.Body)
+	}
+
+	if !IsNotFound(err) {
+		t.Errorf("IsNotFound(%v) = false", err)
+	}
+
+	_, err = c.ReadSource(context.Background(), "denied")
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden || apiErr.Body != nil {
+		t.Errorf("ReadSource() error = %#v, want a 403 *APIError without a json body", err)
+	}
+
+	if IsNotFound(err) {
+		t.Errorf("IsNotFound(%v) = true for a 403", err)
+	}
+}
@@ -81,6 +81,7 @@ func (c *Client) DeleteExternalKnowledge(ctx context.Context, e *entities.Extern
 	path := format("/api/v1/rag/integration/%s/%s", vendor, id)
 
 	_, err := c.deleteWithJson(ctx, c.uri(path))
+	c.cache.invalidate(externalKnowledgeKind)
 	return err
 }
 
@@ -116,6 +117,7 @@ func (c *Client) UpdateExternalKnowledge(ctx context.Context, e *entities.Extern
 	}
 
 	resp, err := c.updateWithJson(ctx, uri, body)
+	c.cache.invalidate(externalKnowledgeKind)
 	if err != nil {
 		return err
 	}
@@ -155,6 +157,7 @@ func (c *Client) CreateExternalKnowledge(ctx context.Context, e *entities.Extern
 	uri := c.uri(format("/api/v1/rag/integration/%s", vendor))
 
 	resp, err := c.createWithJson(ctx, uri, body)
+	c.cache.invalidate(externalKnowledgeKind)
 	if err != nil {
 		return nil, err
 	}
@@ -181,7 +184,7 @@ func (c *Client) ListExternalKnowledge(ctx context.Context, vendor string) ([]*e
 }
 
 // This is called by the state() method
-func (c *Client) externalKnowledge() ([]*vendors.BaseExternalKnowledge, error) {
+func (c *Client) externalKnowledge(_ context.Context) ([]*vendors.BaseExternalKnowledge, error) {
 	// Since we don't have a generic list endpoint, return empty list
 	// Individual vendor lists should be retrieved using ListExternalKnowledge
 	return []*vendors.BaseExternalKnowledge{}, nil
@@ -83,6 +83,11 @@ func equal(str, term string) bool {
 func normalizeJSON(input string) (string, error) {
 	var data interface{}
 
+	// An empty document, e.g. the workflow of an agent webhook, stays empty
+	if len(strings.TrimSpace(input)) == 0 {
+		return "", nil
+	}
+
 	if err := json.Unmarshal([]byte(input), &data); err != nil {
 		return "", err
 	}
@@ -119,6 +124,29 @@ func toListStringType(items []string, err error) types.List {
 	return result
 }
 
+// reference returns the element of configured that names the same object as one of keys,
+// so the spelling used in the configuration survives a round trip. It defaults to fallback.
+func reference(configured types.List, fallback string, keys ...string) string {
+	if configured.IsNull() || configured.IsUnknown() {
+		return fallback
+	}
+
+	for _, v := range configured.Elements() {
+		item, ok := v.(types.String)
+		if !ok || item.IsNull() || item.IsUnknown() {
+			continue
+		}
+
+		for _, key := range keys {
+			if len(key) >= 1 && equal(item.ValueString(), key) {
+				return item.ValueString()
+			}
+		}
+	}
+
+	return fallback
+}
+
 func toMapType(items map[string]string, err error) types.Map {
 	elements := make(map[string]attr.Value)
 	for key, value := range items {
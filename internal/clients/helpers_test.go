@@ -0,0 +1,22 @@
+package clients
+
+import (
+	"testing"
+)
+
+// newTestClient returns a client of the api at cfg.Environment. It sends a test
+// api key unless cfg sets a key or another authentication mode.
+func newTestClient(t *testing.T, cfg Config) *Client {
+	t.Helper()
+
+	if len(cfg.ApiKey) == 0 && len(cfg.Auth.Type) == 0 {
+		cfg.ApiKey = "test-key"
+	}
+
+	c, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	return c
+}
@@ -235,6 +235,7 @@ func (c *Client) DeleteInlineSource(ctx context.Context, e *entities.InlineSourc
 	id := e.Id.ValueString()
 	uri := format(requestUri, id)
 	resp, err := c.deleteResp(ctx, c.uri(uri))
+	c.cache.invalidate(sourcesKind)
 	if err != nil {
 		return err
 	}
@@ -261,6 +262,7 @@ func (c *Client) UpdateInlineSource(ctx context.Context, e *entities.InlineSourc
 		}
 
 		resp, err := c.update(ctx, uri, body)
+		c.cache.invalidate(sourcesKind)
 		if err != nil {
 			return err
 		}
@@ -333,6 +335,7 @@ func (c *Client) CreateInlineSource(ctx context.Context, e *entities.InlineSourc
 		}
 
 		resp, err := c.create(ctx, uri, body)
+		c.cache.invalidate(sourcesKind)
 		if err != nil {
 			return nil, err
 		}
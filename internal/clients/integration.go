@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"io"
 
-	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -95,7 +94,13 @@ func toIntegrationApi(e *entities.IntegrationModel) (*integrationApi, error) {
 
 func toIntegrationModel(e *integrationApi) (*entities.IntegrationModel, error) {
 	var err error
-	id := uuid.NewString()
+
+	// The id is the api uuid or, when the api doesn't return one, the name the api addresses integrations by
+	id := e.UUID
+	if len(id) == 0 {
+		id = e.Name
+	}
+
 	configs := make([]entities.ConfigModel, 0)
 
 	for _, config := range e.Configs {
@@ -132,6 +137,7 @@ func (c *Client) DeleteIntegration(ctx context.Context, e *entities.IntegrationM
 		path := format("/api/v2/integrations/%s", name)
 
 		_, err := c.delete(ctx, c.uri(path))
+		c.cache.invalidate(integrationsKind, channelsKind)
 		return err
 	}
 
@@ -156,6 +162,7 @@ func (c *Client) UpdateIntegration(ctx context.Context, e *entities.IntegrationM
 		uri := c.uri(format("/api/v2/integrations/%s", name))
 
 		resp, err := c.update(ctx, uri, body)
+		c.cache.invalidate(integrationsKind, channelsKind)
 		if err != nil {
 			return err
 		}
@@ -190,7 +197,7 @@ func (c *Client) ReadIntegration(ctx context.Context, name string) (*entities.In
 		if err != nil {
 			return nil, err
 		}
-		return nil, eformat("Integration %s not found", name)
+		return nil, eformat("Integration %s %w", name, ErrNotFound)
 	}
 
 	return entity, nil
@@ -213,6 +220,7 @@ func (c *Client) CreateIntegration(ctx context.Context, e *entities.IntegrationM
 		uri := c.uri("/api/v2/integrations")
 
 		resp, err := c.create(ctx, uri, body)
+		c.cache.invalidate(integrationsKind, channelsKind)
 		if err != nil {
 			return nil, err
 		}
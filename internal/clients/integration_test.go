@@ -0,0 +1,32 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestReadIntegrationKeepsIdentity(t *testing.T) {
+	integrations := map[string]string{
+		"/api/v2/integrations/aws-prod": `{"uuid":"i1","name":"aws-prod","integration_type":"aws","configs":[{"name":"default","is_default":true}]}`,
+		"/api/v2/integrations/jira":     `{"name":"jira","integration_type":"jira","configs":[{"name":"default","is_default":true}]}`,
+	}
+	srv, _ := newListServer(t, func(w http.ResponseWriter, r *http.Request, _ int) {
+		_, _ = w.Write([]byte(integrations[r.URL.Path]))
+	})
+	c := newTestClient(t, Config{Environment: srv.URL, StateCacheTTL: time.Minute})
+
+	for name, want := range map[string]string{"aws-prod": "i1", "jira": "jira"} {
+		for i := 0; i < 2; i++ {
+			entity, err := c.ReadIntegration(context.Background(), name)
+			if err != nil {
+				t.Fatalf("ReadIntegration(%s) error = %v", name, err)
+			}
+
+			if got := entity.ID.ValueString(); got != want {
+				t.Errorf("ReadIntegration(%s) id = %s, want %s", name, got, want)
+			}
+		}
+	}
+}
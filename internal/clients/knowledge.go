@@ -110,6 +110,9 @@ func fromKnowledge(a *knowledge, cs *state) (*entities.KnowledgeModel, error) {
 		Content:     types.StringValue(a.Content),
 		Labels:      toListStringType(a.Labels, err),
 		Description: types.StringValue(a.Description),
+
+		Groups:          toListStringType(nil, err),
+		SupportedAgents: toListStringType(nil, err),
 	}
 
 	if len(a.Groups) >= 1 {
@@ -141,36 +144,112 @@ func fromKnowledge(a *knowledge, cs *state) (*entities.KnowledgeModel, error) {
 	return result, err
 }
 
-func (c *Client) ReadKnowledge(_ context.Context, e *entities.KnowledgeModel) error {
+// knowledgeStateKinds returns the lists toKnowledge and fromKnowledge resolve for the given model.
+func knowledgeStateKinds(a *entities.KnowledgeModel) []stateKind {
+	kinds := make([]stateKind, 0)
+
+	if len(a.Groups.Elements()) >= 1 {
+		kinds = append(kinds, groupsKind)
+	}
+
+	if len(a.SupportedAgents.Elements()) >= 1 {
+		kinds = append(kinds, agentsKind)
+	}
+
+	return kinds
+}
+
+// knowledgeResponseKinds returns the lists fromKnowledge resolves for the given api knowledge.
+func knowledgeResponseKinds(a *knowledge) []stateKind {
+	kinds := make([]stateKind, 0)
+
+	if len(a.Groups) >= 1 {
+		kinds = append(kinds, groupsKind)
+	}
+
+	if len(a.SupportedAgents) >= 1 {
+		kinds = append(kinds, agentsKind)
+	}
+
+	return kinds
+}
+
+// ReadKnowledge hydrates e from the knowledge with its id.
+func (c *Client) ReadKnowledge(ctx context.Context, e *entities.KnowledgeModel) error {
 	if e != nil {
-		cs, err := c.state()
+		id := e.Id.ValueString()
+		if len(id) == 0 {
+			return fmt.Errorf("knowledge id is empty")
+		}
+
+		resp, err := c.read(ctx, c.uri(format("/api/v1/knowledge/%s", id)))
 		if err != nil {
 			return err
 		}
 
-		id := e.Id
-		name := e.Name
+		var a *knowledge
+		if err = json.NewDecoder(resp).Decode(&a); err != nil {
+			return err
+		}
 
-		for _, a := range cs.knowledgeList {
-			if equal(a.Id, id.ValueString()) ||
-				equal(a.Name, name.ValueString()) {
-				e, err = fromKnowledge(a, cs)
-				break
-			}
+		if a == nil {
+			return eformat("knowledge \"%s\" %w", id, ErrNotFound)
 		}
 
-		return err
+		cs, err := c.state(ctx, knowledgeResponseKinds(a)...)
+		if err != nil {
+			return err
+		}
+
+		result, err := fromKnowledge(a, cs)
+		if err != nil {
+			return err
+		}
+
+		*e = *result
+		return nil
 	}
 
 	return fmt.Errorf("param entity (*entities.KnowledgeModel) is nil")
 }
 
+// KnowledgeImportId returns the id of the knowledge with the given id or, failing
+// that, of the only knowledge named key. Only imports should look knowledge up by name.
+func (c *Client) KnowledgeImportId(ctx context.Context, key string) (string, error) {
+	_, err := c.read(ctx, c.uri(format("/api/v1/knowledge/%s", key)))
+	if err == nil || !IsNotFound(err) {
+		return key, err
+	}
+
+	list, err := c.state(ctx, knowledgeKind)
+	if err != nil {
+		return "", err
+	}
+
+	var ids []string
+	for _, a := range list.knowledgeList {
+		if equal(a.Name, key) {
+			ids = append(ids, a.Id)
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", eformat("knowledge \"%s\" %w", key, ErrNotFound)
+	case 1:
+		return ids[0], nil
+	}
+
+	return "", eformat("more than one knowledge is named \"%s\". import it by id instead", key)
+}
+
 func (c *Client) DeleteKnowledge(ctx context.Context, e *entities.KnowledgeModel) error {
 	if e != nil {
 		id := e.Id.ValueString()
 		path := format("/api/v1/knowledge/%s", id)
 
 		_, err := c.delete(ctx, c.uri(path))
+		c.cache.invalidate(knowledgeKind)
 		return err
 	}
 
@@ -179,7 +258,7 @@ func (c *Client) DeleteKnowledge(ctx context.Context, e *entities.KnowledgeModel
 
 func (c *Client) UpdateKnowledge(ctx context.Context, e *entities.KnowledgeModel) error {
 	if e != nil {
-		cs, err := c.state()
+		cs, err := c.state(ctx, knowledgeStateKinds(e)...)
 		if err != nil {
 			return err
 		}
@@ -200,6 +279,7 @@ func (c *Client) UpdateKnowledge(ctx context.Context, e *entities.KnowledgeModel
 		}
 
 		resp, err := c.update(ctx, uri, body)
+		c.cache.invalidate(knowledgeKind)
 		if err != nil {
 			return err
 		}
@@ -218,7 +298,7 @@ func (c *Client) UpdateKnowledge(ctx context.Context, e *entities.KnowledgeModel
 
 func (c *Client) CreateKnowledge(ctx context.Context, e *entities.KnowledgeModel) (*entities.KnowledgeModel, error) {
 	if e != nil {
-		cs, err := c.state()
+		cs, err := c.state(ctx, knowledgeStateKinds(e)...)
 		if err != nil {
 			return nil, err
 		}
@@ -238,6 +318,7 @@ func (c *Client) CreateKnowledge(ctx context.Context, e *entities.KnowledgeModel
 		uri := c.uri("/api/v1/knowledge")
 
 		resp, err := c.create(ctx, uri, body)
+		c.cache.invalidate(knowledgeKind)
 		if err != nil {
 			return nil, err
 		}
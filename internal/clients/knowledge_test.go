@@ -0,0 +1,81 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-kubiya/internal/entities"
+)
+
+func TestReadKnowledgeHydratesEntity(t *testing.T) {
+	lists := map[string]string{
+		"/api/v1/knowledge/k1":  `{"uuid":"k1","name":"runbook","content":"restart it","groups":["g1"]}`,
+		"/api/v1/manage/groups": `[{"uuid":"g1","name":"Admins"}]`,
+	}
+	srv, ls := newListServer(t, func(w http.ResponseWriter, r *http.Request, _ int) {
+		body, ok := lists[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		_, _ = w.Write([]byte(body))
+	})
+	c := newTestClient(t, Config{Environment: srv.URL, StateCacheTTL: time.Minute})
+
+	entity := &entities.KnowledgeModel{Id: types.StringValue("k1"), Name: types.StringValue("other")}
+	if err := c.ReadKnowledge(context.Background(), entity); err != nil {
+		t.Fatalf("ReadKnowledge() error = %v", err)
+	}
+
+	if entity.Name.ValueString() != "runbook" || entity.Content.ValueString() != "restart it" {
+		t.Errorf("ReadKnowledge() = %+v, want the knowledge k1", entity)
+	}
+
+	if want := stringList(t, "Admins"); !entity.Groups.Equal(want) {
+		t.Errorf("groups = %v, want %v", entity.Groups, want)
+	}
+
+	if entity.SupportedAgents.IsNull() {
+		t.Error("supported_agents is null, want an empty list")
+	}
+
+	if n := ls.count("GET /api/v1/knowledge"); n != 0 {
+		t.Errorf("the knowledge list was loaded %d times by a read by id", n)
+	}
+
+	if err := c.ReadKnowledge(context.Background(), &entities.KnowledgeModel{Id: types.StringValue("k2")}); !IsNotFound(err) {
+		t.Errorf("ReadKnowledge() of a missing knowledge error = %v, want a not found error", err)
+	}
+}
+
+func TestKnowledgeImportId(t *testing.T) {
+	lists := map[string]string{
+		"/api/v1/knowledge/k1": `{"uuid":"k1","name":"runbook"}`,
+		"/api/v1/knowledge":    `[{"uuid":"k1","name":"runbook"},{"uuid":"k2","name":"faq"},{"uuid":"k3","name":"FAQ"}]`,
+	}
+	srv, _ := newListServer(t, func(w http.ResponseWriter, r *http.Request, _ int) {
+		body, ok := lists[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		_, _ = w.Write([]byte(body))
+	})
+	c := newTestClient(t, Config{Environment: srv.URL, StateCacheTTL: time.Minute})
+
+	for key, want := range map[string]string{"k1": "k1", "Runbook": "k1"} {
+		if id, err := c.KnowledgeImportId(context.Background(), key); err != nil || id != want {
+			t.Errorf("KnowledgeImportId(%q) = %q, %v, want %q", key, id, err, want)
+		}
+	}
+
+	if _, err := c.KnowledgeImportId(context.Background(), "faq"); err == nil {
+		t.Error("KnowledgeImportId() accepted a name shared by two knowledge items")
+	}
+
+	if _, err := c.KnowledgeImportId(context.Background(), "missing"); !IsNotFound(err) {
+		t.Errorf("KnowledgeImportId() of a missing knowledge error = %v, want a not found error", err)
+	}
+}
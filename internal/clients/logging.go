@@ -0,0 +1,159 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// logSubsystem is the tflog subsystem of the api calls. It logs at the level of
+// TF_LOG_PROVIDER unless TF_LOG_PROVIDER_KUBIYA_API sets its own.
+const logSubsystem = "kubiya_api"
+
+const redacted = "***"
+
+// sensitiveKeys are the json keys whose values are masked in logged bodies.
+var sensitiveKeys = []string{
+	"value",
+	"secret",
+	"password",
+	"token",
+	"access_token",
+	"api_key",
+	"private_key",
+	"client_secret",
+}
+
+// sensitivePaths are the api paths whose bodies are never logged, e.g. the
+// decrypted value of a secret or a runner manifest holding its credentials.
+var sensitivePaths = []string{
+	"/api/v2/secrets/get_value/",
+	"/api/v1/deployment/runners/",
+}
+
+func (c *Client) logContext(ctx context.Context) context.Context {
+	ctx = tflog.NewSubsystem(ctx, logSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER", logSubsystem))
+
+	// the api key is masked wherever it shows up, not only in the Authorization header
+	if len(c.userKey) >= 1 {
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, logSubsystem, c.userKey)
+	}
+
+	return ctx
+}
+
+// logRequest logs req before it is sent, with its headers and body at TRACE only.
+func logRequest(ctx context.Context, req *http.Request) {
+	fields := map[string]any{
+		"method":  req.Method,
+		"url":     req.URL.String(),
+		"headers": logHeaders(req.Header),
+	}
+
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			b, _ := io.ReadAll(body)
+			fields["body"] = logBody(req.URL.Path, b)
+		}
+	}
+
+	tflog.SubsystemTrace(ctx, logSubsystem, "Sending API request", fields)
+}
+
+// logResponse logs the outcome of req, with the response body at TRACE only.
+func logResponse(ctx context.Context, req *http.Request, resp *http.Response, body []byte, err error, start time.Time) {
+	fields := map[string]any{
+		"method":      req.Method,
+		"url":         req.URL.String(),
+		"duration_ms": time.Since(start).Milliseconds(),
+	}
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemDebug(ctx, logSubsystem, "API request failed", fields)
+		return
+	}
+
+	fields["status"] = resp.StatusCode
+	if id := requestId(resp.Header); len(id) >= 1 {
+		fields["request_id"] = id
+	}
+
+	tflog.SubsystemDebug(ctx, logSubsystem, "API request completed", fields)
+
+	fields["body"] = logBody(req.URL.Path, body)
+	tflog.SubsystemTrace(ctx, logSubsystem, "API response body", fields)
+}
+
+func logHeaders(h http.Header) map[string]string {
+	result := make(map[string]string, len(h))
+
+	for key := range h {
+		result[key] = h.Get(key)
+	}
+
+	if _, ok := result["Authorization"]; ok {
+		result["Authorization"] = redacted
+	}
+
+	return result
+}
+
+// logBody returns body as it may be logged, with the sensitive values masked.
+func logBody(path string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	for _, p := range sensitivePaths {
+		if strings.Contains(path, p) {
+			return redacted
+		}
+	}
+
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+
+	b, err := json.Marshal(redact(v))
+	if err != nil {
+		return redacted
+	}
+
+	return string(b)
+}
+
+func redact(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for key, value := range t {
+			if isSensitiveKey(key) {
+				t[key] = redacted
+				continue
+			}
+			t[key] = redact(value)
+		}
+	case []any:
+		for i, value := range t {
+			t[i] = redact(value)
+		}
+	}
+
+	return v
+}
+
+func isSensitiveKey(key string) bool {
+	for _, k := range sensitiveKeys {
+		if strings.EqualFold(key, k) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,80 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRequestLogging(t *testing.T) {
+	srv, _ := newListServer(t, func(w http.ResponseWriter, r *http.Request, _ int) {
+		w.Header().Set("X-Request-Id", "req-1")
+		_, _ = w.Write([]byte(`{"name":"db","value":"hunter2"}`))
+	})
+	c := newTestClient(t, Config{Environment: srv.URL, StateCacheTTL: time.Minute})
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	body := strings.NewReader(`{"name":"db","value":"hunter2","nested":[{"password":"hunter2"}]}`)
+	if _, err := c.createWithJson(ctx, c.uri("/api/v2/secrets"), body); err != nil {
+		t.Fatalf("createWithJson() error = %v", err)
+	}
+
+	if strings.Contains(output.String(), "hunter2") || strings.Contains(output.String(), "test-key") {
+		t.Errorf("the log leaks a secret value or the api key:\n%s", output.String())
+	}
+
+	if !strings.Contains(output.String(), `\"value\":\"***\"`) {
+		t.Errorf("the bodies were not logged at TRACE:\n%s", output.String())
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("MultilineJSONDecode() error = %v", err)
+	}
+
+	var completed map[string]any
+	for _, entry := range entries {
+		if entry["@message"] == "API request completed" {
+			completed = entry
+		}
+	}
+
+	if completed == nil {
+		t.Fatalf("no completed request was logged: %v", entries)
+	}
+
+	if completed["@module"] != "provider."+logSubsystem || completed["method"] != http.MethodPost ||
+		completed["status"] != float64(http.StatusOK) || completed["request_id"] != "req-1" {
+		t.Errorf("completed request logged as %v", completed)
+	}
+
+	if _, ok := completed["duration_ms"]; !ok {
+		t.Errorf("completed request logged without its latency: %v", completed)
+	}
+}
+
+func TestLogBody(t *testing.T) {
+	tests := []struct {
+		path string
+		body string
+		want string
+	}{
+		{path: "/api/v1/agents", body: `{"name":"a","environment_variables":{"token":"t"}}`, want: `{"environment_variables":{"token":"***"},"name":"a"}`},
+		{path: "/api/v2/secrets/get_value/db", body: `"aHVudGVyMg=="`, want: redacted},
+		{path: "/api/v1/agents", body: `not json`, want: `not json`},
+		{path: "/api/v1/agents", body: ``, want: ``},
+	}
+
+	for _, tt := range tests {
+		if got := logBody(tt.path, []byte(tt.body)); got != tt.want {
+			t.Errorf("logBody(%q, %q) = %q, want %q", tt.path, tt.body, got, tt.want)
+		}
+	}
+}
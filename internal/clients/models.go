@@ -0,0 +1,16 @@
+package clients
+
+import (
+	"context"
+	"slices"
+)
+
+// Models returns the LLM models an agent can run, as listed by the supported_llm_models feature flag.
+func (c *Client) Models(ctx context.Context) ([]string, error) {
+	cs, err := c.state(ctx, modelsKind)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Clone(cs.modelList), nil
+}
@@ -0,0 +1,166 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// DefaultMaxRetries is how many times a failed request is retried unless configured otherwise.
+const DefaultMaxRetries = 3
+
+const (
+	defaultRetryWaitMin = 1 * time.Second
+	defaultRetryWaitMax = 30 * time.Second
+
+	maxBackoffShift = 62
+)
+
+type retryPolicy struct {
+	maxRetries int
+	waitMin    time.Duration
+	waitMax    time.Duration
+}
+
+type idempotentKey struct{}
+
+// withIdempotent marks requests made with ctx as safe to retry even when
+// their method is not idempotent (e.g. read-only POST queries).
+func withIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	marked, _ := req.Context().Value(idempotentKey{}).(bool)
+	return marked
+}
+
+// shouldRetry reports whether the outcome of an attempt may be retried.
+// Requests that are not idempotent are only retried when the server
+// certainly did not process them: rate limiting or a failed dial.
+func (p retryPolicy) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if ctxErr := req.Context().Err(); ctxErr != nil {
+		return false
+	}
+
+	idempotent := isIdempotent(req)
+
+	if err != nil {
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return true
+		}
+
+		if !idempotent {
+			return false
+		}
+
+		var netErr net.Error
+		return errors.Is(err, syscall.ECONNRESET) ||
+			errors.Is(err, syscall.ECONNREFUSED) ||
+			errors.Is(err, io.ErrUnexpectedEOF) ||
+			errors.Is(err, io.EOF) ||
+			(errors.As(err, &netErr) && netErr.Timeout())
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent
+	}
+
+	return false
+}
+
+// backoff returns how long to wait before the given retry attempt (starting at 0).
+// A valid Retry-After header takes precedence over the jittered exponential backoff,
+// but never beyond waitMax so a misbehaving server cannot stall the run.
+func (p retryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if wait, ok := retryAfter(resp); ok {
+		return min(wait, p.waitMax)
+	}
+
+	// compare before shifting, waitMin << attempt overflows for large attempts
+	wait := p.waitMax
+	if attempt < maxBackoffShift && p.waitMin <= p.waitMax>>attempt {
+		wait = p.waitMin << attempt
+	}
+
+	// full jitter on the upper half keeps concurrent clients from retrying in lockstep
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil && seconds >= 0 {
+		if seconds > int64(math.MaxInt64/time.Second) {
+			return math.MaxInt64, true
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(at); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// rewindable makes sure the request body can be replayed on retries.
+func rewindable(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody != nil {
+		return nil
+	}
+
+	b, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	req.ContentLength = int64(len(b))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(b)), nil
+	}
+	req.Body, _ = req.GetBody()
+
+	return nil
+}
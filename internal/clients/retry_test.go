@@ -0,0 +1,225 @@
+package clients
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// retryConfig is the config of a client retrying maxRetries times without waiting long.
+func retryConfig(url string, maxRetries int) Config {
+	return Config{
+		Environment:  url,
+		MaxRetries:   maxRetries,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: 5 * time.Millisecond,
+	}
+}
+
+// statusServer answers with the given status codes in order, then with 200 and body.
+func statusServer(t *testing.T, body string, statuses ...int) (*httptest.Server, *int32, *[]string) {
+	t.Helper()
+
+	var (
+		calls    int32
+		mu       sync.Mutex
+		payloads []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&calls, 1))
+
+		b, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		payloads = append(payloads, string(b))
+		mu.Unlock()
+
+		if n <= len(statuses) {
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &calls, &payloads
+}
+
+func TestPostIsNotRetriedOnServerErrors(t *testing.T) {
+	for _, status := range []int{http.StatusBadGateway, http.StatusServiceUnavailable} {
+		srv, calls, _ := statusServer(t, `{}`, status, status)
+		c := newTestClient(t, retryConfig(srv.URL, 3))
+
+		if _, err := c.create(context.Background(), c.uri("/api/v1/agents"), strings.NewReader(`{}`)); err == nil {
+			t.Errorf("status %d: expected an error", status)
+		}
+
+		if got := atomic.LoadInt32(calls); got != 1 {
+			t.Errorf("status %d: expected 1 call, got %d", status, got)
+		}
+	}
+}
+
+func TestPostIsRetriedOnTooManyRequests(t *testing.T) {
+	const payload = `{"name":"agent"}`
+
+	srv, calls, payloads := statusServer(t, `{}`, http.StatusTooManyRequests, http.StatusTooManyRequests)
+	c := newTestClient(t, retryConfig(srv.URL, 3))
+
+	if _, err := c.create(context.Background(), c.uri("/api/v1/agents"), strings.NewReader(payload)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := atomic.LoadInt32(calls); got != 3 {
+		t.Fatalf("expected 3 calls, got %d", got)
+	}
+
+	for i, p := range *payloads {
+		if p != payload {
+			t.Errorf("attempt %d sent body %q, want %q", i, p, payload)
+		}
+	}
+}
+
+func TestPostIsRetriedOnDialErrors(t *testing.T) {
+	srv, calls, _ := statusServer(t, `{}`)
+	c := newTestClient(t, retryConfig(srv.URL, 3))
+
+	var dials int32
+	c.client.Transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if atomic.AddInt32(&dials, 1) == 1 {
+			return nil, &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+		}
+		return http.DefaultTransport.RoundTrip(req)
+	})
+
+	if _, err := c.create(context.Background(), c.uri("/api/v1/agents"), strings.NewReader(`{}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&dials); got != 2 {
+		t.Errorf("expected 2 attempts, got %d", got)
+	}
+
+	if got := atomic.LoadInt32(calls); got != 1 {
+		t.Errorf("expected 1 call to reach the server, got %d", got)
+	}
+}
+
+func TestIdempotentPostIsRetried(t *testing.T) {
+	srv, calls, payloads := statusServer(t, `{"supported_llm_models":"gpt-4o, claude"}`, http.StatusServiceUnavailable)
+	c := newTestClient(t, retryConfig(srv.URL, 3))
+
+	models, err := c.models(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(models) != 2 || models[0] != "gpt-4o" || models[1] != "claude" {
+		t.Errorf("unexpected models: %v", models)
+	}
+
+	if got := atomic.LoadInt32(calls); got != 2 {
+		t.Errorf("expected 2 calls, got %d", got)
+	}
+
+	for i, p := range *payloads {
+		if p != `["supported_llm_models"]` {
+			t.Errorf("attempt %d sent body %q", i, p)
+		}
+	}
+}
+
+func TestPutBodyIsReplayed(t *testing.T) {
+	const payload = `{"name":"knowledge"}`
+
+	srv, calls, payloads := statusServer(t, `{}`, http.StatusBadGateway, http.StatusGatewayTimeout)
+	c := newTestClient(t, retryConfig(srv.URL, 3))
+
+	if _, err := c.update(context.Background(), c.uri("/api/v1/knowledge/id"), strings.NewReader(payload)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := atomic.LoadInt32(calls); got != 3 {
+		t.Fatalf("expected 3 calls, got %d", got)
+	}
+
+	for i, p := range *payloads {
+		if p != payload {
+			t.Errorf("attempt %d sent body %q, want %q", i, p, payload)
+		}
+	}
+}
+
+func TestZeroMaxRetriesDisablesRetries(t *testing.T) {
+	srv, calls, _ := statusServer(t, `[]`, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	c := newTestClient(t, retryConfig(srv.URL, 0))
+
+	if _, err := c.read(context.Background(), c.uri("/api/v1/agents")); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if got := atomic.LoadInt32(calls); got != 1 {
+		t.Errorf("expected 1 call, got %d", got)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	header := func(value string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": []string{value}}}
+	}
+
+	if wait, ok := retryAfter(header("3")); !ok || wait != 3*time.Second {
+		t.Errorf("seconds form: got %s, %v", wait, ok)
+	}
+
+	future := time.Now().Add(90 * time.Second).UTC().Format(http.TimeFormat)
+	if wait, ok := retryAfter(header(future)); !ok || wait <= 80*time.Second || wait > 90*time.Second {
+		t.Errorf("http-date form: got %s, %v", wait, ok)
+	}
+
+	past := time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)
+	if wait, ok := retryAfter(header(past)); !ok || wait != 0 {
+		t.Errorf("past http-date: got %s, %v", wait, ok)
+	}
+
+	for _, value := range []string{"", "soon", "-1"} {
+		if _, ok := retryAfter(header(value)); ok {
+			t.Errorf("%q should not be accepted", value)
+		}
+	}
+}
+
+func TestBackoffIsBounded(t *testing.T) {
+	p := retryPolicy{maxRetries: 100, waitMin: time.Second, waitMax: 30 * time.Second}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"86400"}}}
+	if wait := p.backoff(0, resp); wait != p.waitMax {
+		t.Errorf("Retry-After should be capped at %s, got %s", p.waitMax, wait)
+	}
+
+	for _, attempt := range []int{0, 1, 4, 33, 34, 62, 63, 100} {
+		wait := p.backoff(attempt, nil)
+		if wait <= 0 || wait > p.waitMax {
+			t.Errorf("attempt %d: backoff %s out of range", attempt, wait)
+		}
+		if attempt >= 5 && wait < p.waitMax/2 {
+			t.Errorf("attempt %d: backoff %s should be close to %s", attempt, wait, p.waitMax)
+		}
+	}
+}
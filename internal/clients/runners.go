@@ -4,46 +4,57 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"terraform-provider-kubiya/internal/entities"
 )
 
+// runnerHealth is used internally by the client
+type runnerHealth struct {
+	Error   string `json:"error"`
+	Health  string `json:"health"`
+	Status  string `json:"status"`
+	Version string `json:"version"`
+}
+
+// healthy reports whether a runner component has no error and reports a healthy state.
+func (h runnerHealth) healthy() bool {
+	if len(h.Error) >= 1 {
+		return false
+	}
+
+	for _, v := range []string{h.Health, h.Status} {
+		switch strings.ToLower(v) {
+		case "true", "ok", "healthy":
+			return true
+		}
+	}
+
+	return false
+}
+
 // runner is used internally by the client
 type runner struct {
-	Name                string  `json:"name"`
-	Subject             string  `json:"subject"`
-	Namespace           string  `json:"namespace"`
-	RunnerType          string  `json:"runner_type"`
-	UserKeyId           string  `json:"user_key_id"`
-	Version             int     `json:"version"`
-	Description         string  `json:"description"`
-	AuthenticationType  string  `json:"authentication_type"`
-	ManagedBy           string  `json:"managed_by"`
-	TaskId              string  `json:"task_id"`
-	WssUrl              string  `json:"wss_url"`
-	KubernetesNamespace string  `json:"kubernetes_namespace"`
-	GatewayUrl          *string `json:"gateway_url"`
-	GatewayPassword     *string `json:"gateway_password"`
-	AgentManagerHealth  struct {
-		Error   string `json:"error"`
-		Health  string `json:"health"`
-		Status  string `json:"status"`
-		Version string `json:"version"`
-	} `json:"agent_manager_health"`
-	RunnerHealth struct {
-		Error   string `json:"error"`
-		Health  string `json:"health"`
-		Status  string `json:"status"`
-		Version string `json:"version"`
-	} `json:"runner_health"`
-	ToolManagerHealth struct {
-		Error   string `json:"error"`
-		Health  string `json:"health"`
-		Status  string `json:"status"`
-		Version string `json:"version"`
-	} `json:"tool_manager_health"`
+	Name                string       `json:"name"`
+	Subject             string       `json:"subject"`
+	Namespace           string       `json:"namespace"`
+	RunnerType          string       `json:"runner_type"`
+	UserKeyId           string       `json:"user_key_id"`
+	Version             int          `json:"version"`
+	Description         string       `json:"description"`
+	AuthenticationType  string       `json:"authentication_type"`
+	ManagedBy           string       `json:"managed_by"`
+	TaskId              string       `json:"task_id"`
+	WssUrl              string       `json:"wss_url"`
+	KubernetesNamespace string       `json:"kubernetes_namespace"`
+	GatewayUrl          *string      `json:"gateway_url"`
+	GatewayPassword     *string      `json:"gateway_password"`
+	AgentManagerHealth  runnerHealth `json:"agent_manager_health"`
+	RunnerHealth        runnerHealth `json:"runner_health"`
+	ToolManagerHealth   runnerHealth `json:"tool_manager_health"`
 }
 
 func (c *Client) ReadRunner(ctx context.Context, entity *entities.RunnerModel) error {
@@ -51,25 +62,45 @@ func (c *Client) ReadRunner(ctx context.Context, entity *entities.RunnerModel) e
 		return fmt.Errorf("param entity (*entities.RunnerModel) is nil")
 	}
 
-	const uri = "/api/v3/runners/%s/describe"
-	name := entity.Name.ValueString()
-
-	resp, err := c.read(ctx, c.uri(format(uri, name)))
+	r, err := c.FindRunner(ctx, entity.Name.ValueString())
 	if err != nil {
 		return err
 	}
 
-	var r runner
-	if err := json.NewDecoder(resp).Decode(&r); err != nil {
-		return err
+	entity.Name = r.Name
+	entity.RunnerType = r.RunnerType
+	entity.Namespace = r.Namespace
+	entity.Description = r.Description
+	entity.Version = r.Version
+	entity.AuthenticationType = r.AuthenticationType
+	entity.WssUrl = r.WssUrl
+	entity.GatewayUrl = r.GatewayUrl
+	entity.KubernetesNamespace = r.KubernetesNamespace
+	entity.ManagedBy = r.ManagedBy
+	entity.Healthy = r.Healthy
+	entity.RunnerHealth = r.RunnerHealth
+	entity.AgentManagerHealth = r.AgentManagerHealth
+	entity.ToolManagerHealth = r.ToolManagerHealth
+
+	// The manifest is fetched once, when the runner is created or imported, and then kept in the state.
+	if entity.InstallManifest.IsNull() || entity.InstallManifest.IsUnknown() {
+		manifest, err := c.RunnerManifest(ctx, entity.Name.ValueString())
+		if err != nil {
+			return fmt.Errorf("failed to download the runner install manifest: %w", err)
+		}
+		entity.InstallManifest = types.StringValue(manifest)
 	}
 
-	entity.Name = types.StringValue(r.Name)
-	entity.RunnerType = types.StringValue(r.RunnerType)
-
 	return nil
 }
 
+// RunnerManifest returns the Kubernetes manifest that installs the runner.
+func (c *Client) RunnerManifest(ctx context.Context, name string) (string, error) {
+	const uri = "/api/v1/deployment/runners/%s"
+
+	return c.download(ctx, c.uri(format(uri, name)))
+}
+
 func (c *Client) DeleteRunner(ctx context.Context, entity *entities.RunnerModel) error {
 	if entity != nil {
 		const (
@@ -78,6 +109,7 @@ func (c *Client) DeleteRunner(ctx context.Context, entity *entities.RunnerModel)
 		name := entity.Name.ValueString()
 
 		_, err := c.delete(ctx, c.uri(format(uri, name)))
+		c.cache.invalidate(runnersKind)
 		return err
 	}
 
@@ -105,14 +137,92 @@ func (c *Client) CreateRunner(ctx context.Context, entity *entities.RunnerModel)
 
 	reqUri := c.uri(format(uri, name))
 	_, err = c.create(ctx, reqUri, body)
+	c.cache.invalidate(runnersKind)
 	if err != nil {
 		return nil, err
 	}
 
-	// Now call describe to get the runner type
+	// Now call describe to get the runner details and its install manifest
 	if err := c.ReadRunner(ctx, entity); err != nil {
 		return nil, fmt.Errorf("runner created but failed to read details: %v", err)
 	}
 
 	return entity, nil
 }
+
+func fromRunnerHealth(h runnerHealth) entities.RunnerHealthModel {
+	return entities.RunnerHealthModel{
+		Error:   types.StringValue(h.Error),
+		Health:  types.StringValue(h.Health),
+		Status:  types.StringValue(h.Status),
+		Version: types.StringValue(h.Version),
+	}
+}
+
+func fromRunner(r *runner) *entities.RunnerDataModel {
+	var gatewayUrl string
+	if r.GatewayUrl != nil {
+		gatewayUrl = *r.GatewayUrl
+	}
+
+	healthy := r.RunnerHealth.healthy() &&
+		r.AgentManagerHealth.healthy() &&
+		r.ToolManagerHealth.healthy()
+
+	return &entities.RunnerDataModel{
+		Name:                types.StringValue(r.Name),
+		RunnerType:          types.StringValue(r.RunnerType),
+		Namespace:           types.StringValue(r.Namespace),
+		Description:         types.StringValue(r.Description),
+		Version:             types.Int64Value(int64(r.Version)),
+		AuthenticationType:  types.StringValue(r.AuthenticationType),
+		WssUrl:              types.StringValue(r.WssUrl),
+		GatewayUrl:          types.StringValue(gatewayUrl),
+		KubernetesNamespace: types.StringValue(r.KubernetesNamespace),
+		ManagedBy:           types.StringValue(r.ManagedBy),
+		Healthy:             types.BoolValue(healthy),
+		RunnerHealth:        fromRunnerHealth(r.RunnerHealth),
+		AgentManagerHealth:  fromRunnerHealth(r.AgentManagerHealth),
+		ToolManagerHealth:   fromRunnerHealth(r.ToolManagerHealth),
+	}
+}
+
+// FindRunner describes the runner with the given name, including its current health.
+func (c *Client) FindRunner(ctx context.Context, name string) (*entities.RunnerDataModel, error) {
+	const uri = "/api/v3/runners/%s/describe"
+
+	resp, err := c.read(ctx, c.uri(format(uri, name)))
+	if err != nil {
+		return nil, err
+	}
+
+	var r runner
+	if err := json.NewDecoder(resp).Decode(&r); err != nil {
+		return nil, err
+	}
+
+	if len(r.Name) == 0 {
+		r.Name = name
+	}
+
+	return fromRunner(&r), nil
+}
+
+// ListRunners returns every runner of the organization ordered by name.
+func (c *Client) ListRunners(ctx context.Context) ([]*entities.RunnerDataModel, error) {
+	cs, err := c.state(ctx, runnersKind)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entities.RunnerDataModel, 0, len(cs.runnerList))
+	for _, r := range cs.runnerList {
+		result = append(result, fromRunner(r))
+	}
+
+	slices.SortStableFunc(result, func(a, b *entities.RunnerDataModel) int {
+		return strings.Compare(a.Name.ValueString(), b.Name.ValueString())
+	})
+
+	return result, nil
+}
@@ -0,0 +1,42 @@
+package clients
+
+import "testing"
+
+func TestRunnerHealth(t *testing.T) {
+	healthy := runnerHealth{Health: "true", Status: "ok"}
+
+	tests := []struct {
+		name   string
+		runner runner
+		want   bool
+	}{
+		{
+			name:   "all components healthy",
+			runner: runner{RunnerHealth: healthy, AgentManagerHealth: healthy, ToolManagerHealth: runnerHealth{Status: "OK"}},
+			want:   true,
+		},
+		{
+			name:   "component reports an error",
+			runner: runner{RunnerHealth: runnerHealth{Health: "true", Error: "timeout"}, AgentManagerHealth: healthy, ToolManagerHealth: healthy},
+			want:   false,
+		},
+		{
+			name:   "component reports unhealthy",
+			runner: runner{RunnerHealth: healthy, AgentManagerHealth: runnerHealth{Health: "false"}, ToolManagerHealth: healthy},
+			want:   false,
+		},
+		{
+			name:   "no health reported",
+			runner: runner{},
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fromRunner(&tt.runner).Healthy.ValueBool(); got != tt.want {
+				t.Errorf("healthy = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
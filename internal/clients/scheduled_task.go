@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"slices"
 	"time"
 
@@ -22,6 +23,14 @@ const (
 	monthly = "monthly"
 )
 
+const (
+	statusActive = "active"
+	statusPaused = "paused"
+)
+
+// upcomingRuns is how many of the next runs of a task are computed.
+const upcomingRuns = 5
+
 var (
 	cronOptions = []string{daily, hourly, weekly, monthly}
 )
@@ -42,13 +51,85 @@ type scheduledTask struct {
 }
 
 type createScheduledTaskRequest struct {
-	Email         string    `json:"user_email"`
-	ChannelId     string    `json:"channel_id"`
-	CronString    string    `json:"cron_string"`
-	ScheduledTime time.Time `json:"schedule_time"`
-	Agent         string    `json:"selected_agent"`
-	Description   string    `json:"task_description"`
-	Org           string    `json:"organization_name"`
+	Email         string         `json:"user_email"`
+	ChannelId     string         `json:"channel_id"`
+	CronString    string         `json:"cron_string"`
+	ScheduledTime time.Time      `json:"schedule_time"`
+	Agent         string         `json:"selected_agent"`
+	Description   string         `json:"task_description"`
+	Org           string         `json:"organization_name"`
+	Status        string         `json:"status,omitempty"`
+	Parameters    map[string]any `json:"parameters,omitempty"`
+}
+
+// managedKeys are the task parameters the api derives from the other attributes.
+var managedKeys = []string{"context", "message_text", "cron_string", "repeat"}
+
+// managedParameters are the task parameters the api derives from the other
+// attributes. They are sent along with the configured parameters so replacing
+// the parameters never drops them.
+func managedParameters(r *createScheduledTaskRequest, taskContext, message string) map[string]any {
+	result := map[string]any{
+		"context":      taskContext,
+		"message_text": message,
+	}
+
+	if len(r.CronString) >= 1 {
+		result["cron_string"] = r.CronString
+	}
+
+	return result
+}
+
+// taskParameters returns the parameters of a task read from the api. With
+// configured parameters in prior only the configured keys are kept, in the
+// shape they were configured in. Otherwise every parameter is kept but the ones
+// the api manages on its own.
+func taskParameters(read map[string]any, prior types.Dynamic) (types.Dynamic, error) {
+	result := map[string]any{}
+
+	if prior.IsNull() || prior.IsUnknown() || prior.IsUnderlyingValueNull() || prior.IsUnderlyingValueUnknown() {
+		for key, value := range read {
+			if !slices.Contains(managedKeys, key) {
+				result[key] = value
+			}
+		}
+
+		value, err := toDynamic(result, nil)
+		if err != nil {
+			return types.DynamicNull(), err
+		}
+
+		return types.DynamicValue(value), nil
+	}
+
+	configured, err := fromDynamic(prior)
+	if err != nil {
+		return types.DynamicNull(), err
+	}
+
+	keys, _ := configured.(map[string]any)
+	for key, value := range read {
+		if _, ok := keys[key]; ok {
+			result[key] = value
+		}
+	}
+
+	value, err := toDynamic(result, prior.UnderlyingValue().Type(context.Background()))
+	if err != nil {
+		return types.DynamicNull(), err
+	}
+
+	return types.DynamicValue(value), nil
+}
+
+// stringOr returns the value of s, or fallback when s is not set.
+func stringOr(s types.String, fallback string) string {
+	if s.IsNull() || s.IsUnknown() || len(s.ValueString()) == 0 {
+		return fallback
+	}
+
+	return s.ValueString()
 }
 
 func toDailyCron(t time.Time) string {
@@ -71,9 +152,76 @@ func toMonthlyCron(t time.Time) string {
 	return fmt.Sprintf(layout, t.Minute(), t.Hour(), t.Day())
 }
 
+// location returns the time zone of a task, UTC when none is configured.
+func location(timezone types.String) (*time.Location, error) {
+	if timezone.IsNull() || timezone.IsUnknown() || len(timezone.ValueString()) == 0 {
+		return time.UTC, nil
+	}
+
+	return time.LoadLocation(timezone.ValueString())
+}
+
+// parseScheduledTime parses an RFC3339 time, or a wall clock time such as
+// 2024-05-01T09:00:00 in loc.
+func parseScheduledTime(value string, loc *time.Location) (time.Time, error) {
+	const layout = "2006-01-02T15:04:05"
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.ParseInLocation(layout, value, loc)
+	if err != nil {
+		return time.Time{}, eformat("scheduled_time: %s is not valid time. use 2006-01-02T15:04:05 or an RFC3339 time", value)
+	}
+
+	if t.IsZero() {
+		return time.Time{}, eformat("scheduled_time: %s is not valid time.", value)
+	}
+
+	return t, nil
+}
+
+// cronString returns the cron expression of a repeating task, or "".
+func (t *scheduledTask) cronString() string {
+	if repeat, ok := t.Parameters["repeat"].(bool); !ok || !repeat {
+		return ""
+	}
+
+	cron, _ := t.Parameters["cron_string"].(string)
+	return cron
+}
+
+// nextRuns returns the next runs of the task after now as RFC3339 times in loc.
+func nextRuns(t *scheduledTask, loc *time.Location, now time.Time) (types.List, error) {
+	var runs []time.Time
+
+	if cron := t.cronString(); len(cron) >= 1 {
+		// the api evaluates the schedule in utc
+		next, err := entities.UpcomingCronRuns(cron, now.UTC(), upcomingRuns)
+		if err != nil {
+			return types.ListNull(types.StringType), err
+		}
+		runs = next
+	} else if at, err := parseScheduledTime(t.ScheduledTime, time.UTC); err == nil && at.After(now) {
+		runs = append(runs, at)
+	}
+
+	result := make([]string, 0, len(runs))
+	for _, run := range runs {
+		result = append(result, run.In(loc).Format(time.RFC3339))
+	}
+
+	return toListStringType(result, nil), nil
+}
+
 func newScheduledTask(body io.Reader) (*scheduledTask, error) {
 	var result scheduledTask
-	if err := json.NewDecoder(body).Decode(&result); err != nil {
+
+	// numbers keep their precision in the parameters
+	decoder := json.NewDecoder(body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&result); err != nil {
 		return nil, err
 	}
 
@@ -81,60 +229,39 @@ func newScheduledTask(body io.Reader) (*scheduledTask, error) {
 }
 
 func fromScheduledTask(a *scheduledTask) (*entities.ScheduledTaskModel, error) {
-	const (
-		layout = "2006-01-02T15:04:05"
-	)
-
 	var err error
 	result := &entities.ScheduledTaskModel{
 		Id:                types.StringValue(a.Id),
 		UUID:              types.StringValue(a.UUID),
 		Email:             types.StringValue(a.Email),
+		Agent:             types.StringValue(a.Agent),
+		Description:       types.StringValue(a.Description),
 		Repeat:            types.StringValue(""),
 		Status:            types.StringValue(a.Status),
+		Enabled:           types.BoolValue(!equal(a.Status, statusPaused)),
 		TaskType:          types.StringValue(a.TaskType),
-		ChannelId:         types.StringValue(a.ChannelName),
+		Channel:           types.StringValue(channelName(a.ChannelName)),
+		ChannelId:         types.StringValue(a.ChannelId),
 		ScheduledTime:     types.StringValue(a.ScheduledTime),
 		NextScheduledTime: types.StringValue(a.NextScheduledTime),
+		UpcomingRuns:      types.ListNull(types.StringType),
 	}
 
-	cron := ""
-	parameters := map[string]string{}
-	for key, val := range a.Parameters {
-		if key == "repeat" {
-			if boolean, ok := val.(bool); ok && boolean {
-				if _, ok = a.Parameters["cron_string"]; ok {
-					item := a.Parameters["cron_string"]
-					if _, ok = a.Parameters["cron_string"].(string); ok {
-						cron = item.(string)
-						if e := result.ParseCron(item.(string)); e != nil {
-							err = errors.Join(err, e)
-							continue
-						}
-					}
-				}
-			}
-		}
+	cron := a.cronString()
+	if e := result.ParseCron(cron); e != nil {
+		err = errors.Join(err, e)
+	}
 
-		if key == "context" {
-			if str, ok := val.(string); ok {
-				result.Agent = types.StringValue(str)
-			}
-		}
+	taskContext, _ := a.Parameters["context"].(string)
+	message, _ := a.Parameters["message_text"].(string)
+	result.Context = types.StringValue(taskContext)
+	result.MessageText = types.StringValue(message)
 
-		if key == "message_text" {
-			if str, ok := val.(string); ok {
-				result.Description = types.StringValue(str)
-			}
-		}
+	parameters, e := taskParameters(a.Parameters, types.DynamicNull())
+	result.Parameters = parameters
+	err = errors.Join(err, e)
 
-		if str, ok := val.(string); ok {
-			parameters[key] = str
-		}
-	}
-	result.Parameters = toMapType(parameters, err)
-
-	if t, _ := time.Parse(layout, a.ScheduledTime); t.IsZero() {
+	if _, e := parseScheduledTime(a.ScheduledTime, time.UTC); e != nil {
 		result.Repeat = types.StringValue(cron)
 		result.ScheduledTime = types.StringValue(empty)
 	}
@@ -152,25 +279,14 @@ func createScheduledTask(e *entities.ScheduledTaskModel) (*createScheduledTaskRe
 		Description: e.Description.ValueString(),
 	}
 
-	scheduledTime := e.ScheduledTime.ValueString()
-	parseTime := func(t, f string, e error) (time.Time, error) {
-		if e != nil {
-			return time.Time{}, e
-		}
-
-		layout := "2006-01-02T15:04:05"
-		ts, parseError := time.Parse(layout, t)
-		if parseError != nil {
-			return time.Time{}, parseError
-		}
-
-		if ts.IsZero() {
-			return time.Time{}, eformat("%s: %s is not valid time.", f, t)
-		}
-
-		return ts, nil
+	loc, err := location(e.Timezone)
+	if err != nil {
+		return nil, err
 	}
-	result.ScheduledTime, err = parseTime(scheduledTime, "scheduled_time", err)
+
+	// the api evaluates the schedule in utc, a preset repeats the utc time of scheduled_time
+	scheduledTime, err := parseScheduledTime(e.ScheduledTime.ValueString(), loc)
+	result.ScheduledTime = scheduledTime.UTC()
 
 	if !result.ScheduledTime.IsZero() && err == nil {
 		switch e.Repeat.ValueString() {
@@ -187,12 +303,113 @@ func createScheduledTask(e *entities.ScheduledTaskModel) (*createScheduledTaskRe
 
 	if cron := e.Repeat.ValueString(); !slices.Contains(cronOptions, cron) && len(cron) > 0 {
 		err = nil
-		result.CronString = cron
+		result.CronString = entities.ExpandCronMacro(cron)
+	}
+
+	if !e.Enabled.IsNull() && !e.Enabled.IsUnknown() {
+		result.Status = statusActive
+		if !e.Enabled.ValueBool() {
+			result.Status = statusPaused
+		}
+	}
+
+	// the parameters are only sent when there is something to set, sending them replaces the ones of the api
+	taskContext, message := stringOr(e.Context, result.Agent), stringOr(e.MessageText, result.Description)
+	configured := !e.Parameters.IsNull() && !e.Parameters.IsUnknown()
+
+	if configured || taskContext != result.Agent || message != result.Description {
+		result.Parameters = managedParameters(result, taskContext, message)
+	}
+
+	if configured {
+		value, err := fromDynamic(e.Parameters)
+		if err != nil {
+			return nil, eformat("parameters: %w", err)
+		}
+
+		parameters, ok := value.(map[string]any)
+		if !ok && value != nil {
+			return nil, eformat("parameters must be an object or a map")
+		}
+
+		maps.Copy(result.Parameters, parameters)
 	}
 
 	return result, err
 }
 
+// defaultTaskText fills in the agent, description, context and message text of
+// a task the api left out. The agent and the description default to the
+// configured ones, the context to the agent and the message text to the
+// description, the way the api fills them in.
+func defaultTaskText(entity, prior *entities.ScheduledTaskModel) {
+	if prior != nil {
+		entity.Agent = types.StringValue(stringOr(entity.Agent, prior.Agent.ValueString()))
+		entity.Description = types.StringValue(stringOr(entity.Description, prior.Description.ValueString()))
+	}
+
+	// without prior state, e.g. on import, the context is the best guess for the agent
+	entity.Agent = types.StringValue(stringOr(entity.Agent, entity.Context.ValueString()))
+	entity.Description = types.StringValue(stringOr(entity.Description, entity.MessageText.ValueString()))
+
+	entity.Context = types.StringValue(stringOr(entity.Context, entity.Agent.ValueString()))
+	entity.MessageText = types.StringValue(stringOr(entity.MessageText, entity.Description.ValueString()))
+}
+
+// taskChannelId returns the id of the channel of a task, looking a channel given by name up.
+func (c *Client) taskChannelId(ctx context.Context, e *entities.ScheduledTaskModel) (string, error) {
+	id, name := e.ChannelId.ValueString(), e.Channel.ValueString()
+	if len(id) >= 1 || len(name) == 0 {
+		return id, nil
+	}
+
+	cs, err := c.state(ctx, channelsKind)
+	if err != nil {
+		return "", err
+	}
+
+	return resolveChannel(cs, name)
+}
+
+// readTaskChannel completes the channel id and name of a task read from the api,
+// which may answer with only one of them. The configured spelling of the name is kept.
+func (c *Client) readTaskChannel(ctx context.Context, entity, prior *entities.ScheduledTaskModel) {
+	id, name := entity.ChannelId.ValueString(), entity.Channel.ValueString()
+
+	if prior != nil {
+		sameId := len(id) >= 1 && id == prior.ChannelId.ValueString()
+		sameName := len(name) >= 1 && equal(name, channelName(prior.Channel.ValueString()))
+
+		if sameName && len(id) == 0 {
+			id = prior.ChannelId.ValueString()
+		}
+		if sameId && len(name) == 0 {
+			name = channelName(prior.Channel.ValueString())
+		}
+	}
+
+	// a failed lookup leaves the channel as the api answered, the read itself still succeeds
+	if (len(id) == 0) != (len(name) == 0) {
+		if cs, err := c.state(ctx, channelsKind); err == nil {
+			key := id
+			if len(key) == 0 {
+				key = name
+			}
+
+			if ch := findChannel(cs, key); ch != nil {
+				id, name = ch.Id, ch.Name
+			}
+		}
+	}
+
+	entity.ChannelId = types.StringValue(id)
+	entity.Channel = types.StringValue(name)
+
+	if prior != nil && len(name) >= 1 && equal(name, channelName(prior.Channel.ValueString())) {
+		entity.Channel = prior.Channel
+	}
+}
+
 func (c *Client) DeleteScheduledTask(ctx context.Context, e *entities.ScheduledTaskModel) error {
 	if e != nil {
 		id := e.Id.ValueString()
@@ -205,7 +422,8 @@ func (c *Client) DeleteScheduledTask(ctx context.Context, e *entities.ScheduledT
 	return fmt.Errorf("param entity (*entities.ScheduledTaskModel) is nil")
 }
 
-func (c *Client) ReadScheduledTask(ctx context.Context, id string) (*entities.ScheduledTaskModel, error) {
+// ReadScheduledTask reads the task with the given id. prior is the last known state, it may be nil.
+func (c *Client) ReadScheduledTask(ctx context.Context, id string, prior *entities.ScheduledTaskModel) (*entities.ScheduledTaskModel, error) {
 	path := format("/api/v1/scheduled_tasks/%s", id)
 
 	resp, err := c.read(ctx, c.uri(path))
@@ -224,7 +442,37 @@ func (c *Client) ReadScheduledTask(ctx context.Context, id string) (*entities.Sc
 			return nil, err
 		}
 
-		return nil, eformat("ScheduledTask %s not found", id)
+		return nil, eformat("ScheduledTask %s %w", id, ErrNotFound)
+	}
+
+	defaultTaskText(entity, prior)
+	c.readTaskChannel(ctx, entity, prior)
+
+	loc := time.UTC
+	if prior != nil {
+		// a custom cron matching a preset is read back as the preset, keep the configured spelling
+		if cron := prior.Repeat.ValueString(); !slices.Contains(cronOptions, cron) && len(cron) > 0 {
+			entity.Repeat = types.StringValue(cron)
+		}
+		if entity.Parameters, err = taskParameters(r.Parameters, prior.Parameters); err != nil {
+			return nil, err
+		}
+
+		if loc, err = location(prior.Timezone); err != nil {
+			return nil, err
+		}
+		entity.Timezone = prior.Timezone
+
+		// the api answers in utc, keep the configured spelling of the same time
+		configured, e1 := parseScheduledTime(prior.ScheduledTime.ValueString(), loc)
+		read, e2 := parseScheduledTime(entity.ScheduledTime.ValueString(), time.UTC)
+		if e1 == nil && e2 == nil && configured.Equal(read) {
+			entity.ScheduledTime = prior.ScheduledTime
+		}
+	}
+
+	if entity.UpcomingRuns, err = nextRuns(r, loc, time.Now()); err != nil {
+		return nil, err
 	}
 
 	return entity, nil
@@ -237,6 +485,10 @@ func (c *Client) CreateScheduledTask(ctx context.Context, e *entities.ScheduledT
 			return nil, err
 		}
 
+		if data.ChannelId, err = c.taskChannelId(ctx, e); err != nil {
+			return nil, err
+		}
+
 		body, err := toJson(data)
 		if err != nil {
 			return nil, err
@@ -256,16 +508,7 @@ func (c *Client) CreateScheduledTask(ctx context.Context, e *entities.ScheduledT
 		}
 
 		if id, ok := tmp["task_id"]; ok {
-			var entity *entities.ScheduledTaskModel
-			if entity, err = c.ReadScheduledTask(ctx, id); err != nil {
-				return nil, err
-			}
-
-			if cron := e.Repeat.ValueString(); !slices.Contains(cronOptions, cron) && len(cron) > 0 {
-				entity.Repeat = types.StringValue(cron)
-			}
-
-			return entity, nil
+			return c.ReadScheduledTask(ctx, id, e)
 		}
 
 		return nil, eformat("failed to createWithQueryParams scheduled task")
@@ -273,3 +516,32 @@ func (c *Client) CreateScheduledTask(ctx context.Context, e *entities.ScheduledT
 
 	return e, fmt.Errorf("param entity (*entities.ScheduledTaskModel) is nil")
 }
+
+func (c *Client) UpdateScheduledTask(ctx context.Context, e *entities.ScheduledTaskModel) (*entities.ScheduledTaskModel, error) {
+	if e != nil {
+		data, err := createScheduledTask(e)
+		if err != nil {
+			return nil, err
+		}
+
+		if data.ChannelId, err = c.taskChannelId(ctx, e); err != nil {
+			return nil, err
+		}
+
+		body, err := toJson(data)
+		if err != nil {
+			return nil, err
+		}
+
+		id := e.Id.ValueString()
+		uri := c.uri(format("/api/v1/scheduled_tasks/%s", id))
+
+		if _, err = c.update(ctx, uri, body); err != nil {
+			return nil, err
+		}
+
+		return c.ReadScheduledTask(ctx, id, e)
+	}
+
+	return nil, fmt.Errorf("param entity (*entities.ScheduledTaskModel) is nil")
+}
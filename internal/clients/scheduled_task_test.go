@@ -0,0 +1,321 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"maps"
+	"math/big"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-kubiya/internal/entities"
+)
+
+func TestUpdateScheduledTask(t *testing.T) {
+	const task = `{"task_id":"t1","task_uuid":"u1","user_email":"a@b.c","channel_id":"C024BE91L","channel_name":"ops",
+		"task_description":"weekday digest","status":"active","task_type":"scheduled",
+		"parameters":{"context":"deployer","message_text":"weekday digest","repeat":true,"cron_string":"0 9 * * 1-5","team":"sre"}}`
+
+	var sent createScheduledTaskRequest
+	srv, ls := newListServer(t, func(w http.ResponseWriter, r *http.Request, _ int) {
+		if r.Method == http.MethodPut {
+			b, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(b, &sent); err != nil {
+				t.Errorf("the update body is not json: %s", b)
+			}
+		}
+		_, _ = w.Write([]byte(task))
+	})
+	c := newTestClient(t, Config{Environment: srv.URL, StateCacheTTL: time.Minute})
+
+	plan := &entities.ScheduledTaskModel{
+		Id:            types.StringValue("t1"),
+		Agent:         types.StringValue("deployer"),
+		Channel:       types.StringValue("ops"),
+		ChannelId:     types.StringValue("C024BE91L"),
+		Description:   types.StringValue("weekday digest"),
+		Repeat:        types.StringValue("0 9 * * 1-5"),
+		ScheduledTime: types.StringValue(""),
+		Parameters:    types.DynamicValue(toMapType(map[string]string{"team": "sre"}, nil)),
+	}
+
+	result, err := c.UpdateScheduledTask(context.Background(), plan)
+	if err != nil {
+		t.Fatalf("UpdateScheduledTask() error = %v", err)
+	}
+
+	if n := ls.count("PUT /api/v1/scheduled_tasks/t1"); n != 1 {
+		t.Errorf("the task was updated %d times, want 1", n)
+	}
+
+	if sent.Description != "weekday digest" || sent.ChannelId != "C024BE91L" || sent.CronString != "0 9 * * 1-5" {
+		t.Errorf("sent %+v", sent)
+	}
+
+	wantSent := map[string]any{
+		"context":      "deployer",
+		"message_text": "weekday digest",
+		"cron_string":  "0 9 * * 1-5",
+		"team":         "sre",
+	}
+	if !maps.Equal(sent.Parameters, wantSent) {
+		t.Errorf("sent parameters %v, want %v", sent.Parameters, wantSent)
+	}
+
+	if result.Repeat.ValueString() != "0 9 * * 1-5" {
+		t.Errorf("repeat = %s, want the configured cron", result.Repeat)
+	}
+
+	// the api adds its own parameters, they may not show up as a change of the configuration
+	if !result.Parameters.Equal(plan.Parameters) {
+		t.Errorf("parameters = %v, want %v", result.Parameters, plan.Parameters)
+	}
+
+	imported, err := c.ReadScheduledTask(context.Background(), "t1", nil)
+	if err != nil {
+		t.Fatalf("ReadScheduledTask() error = %v", err)
+	}
+
+	// without prior state every parameter is kept, the ones with attributes of their own aside
+	want := types.DynamicValue(types.ObjectValueMust(
+		map[string]attr.Type{"team": types.StringType},
+		map[string]attr.Value{"team": types.StringValue("sre")},
+	))
+	if !imported.Parameters.Equal(want) {
+		t.Errorf("parameters without prior state = %v, want %v", imported.Parameters, want)
+	}
+
+	if imported.Agent.ValueString() != "deployer" || imported.Context.ValueString() != "deployer" ||
+		imported.MessageText.ValueString() != "weekday digest" || !imported.Enabled.ValueBool() {
+		t.Errorf("imported %+v", imported)
+	}
+}
+
+func TestUpdateScheduledTaskKeepsApiParameters(t *testing.T) {
+	var sent map[string]any
+	srv, _ := newListServer(t, func(w http.ResponseWriter, r *http.Request, _ int) {
+		if r.Method == http.MethodPut {
+			_ = json.NewDecoder(r.Body).Decode(&sent)
+		}
+		_, _ = w.Write([]byte(`{"task_id":"t1","task_description":"digest","parameters":{"context":"deployer"}}`))
+	})
+	c := newTestClient(t, Config{Environment: srv.URL, StateCacheTTL: time.Minute})
+
+	plan := &entities.ScheduledTaskModel{
+		Id:            types.StringValue("t1"),
+		Agent:         types.StringValue("deployer"),
+		Description:   types.StringValue("digest"),
+		Repeat:        types.StringValue("0 9 * * *"),
+		ScheduledTime: types.StringValue(""),
+		Parameters:    types.DynamicUnknown(),
+	}
+
+	if _, err := c.UpdateScheduledTask(context.Background(), plan); err != nil {
+		t.Fatalf("UpdateScheduledTask() error = %v", err)
+	}
+
+	if _, ok := sent["parameters"]; ok {
+		t.Errorf("parameters that were not configured were sent: %v", sent["parameters"])
+	}
+}
+
+func TestScheduledTaskParameters(t *testing.T) {
+	var sent map[string]any
+	srv, _ := newListServer(t, func(w http.ResponseWriter, r *http.Request, _ int) {
+		if r.Method == http.MethodPut {
+			_ = json.NewDecoder(r.Body).Decode(&sent)
+			return
+		}
+
+		// the api echoes the parameters, with the status of a paused task
+		parameters, _ := json.Marshal(sent["parameters"])
+		_, _ = w.Write([]byte(`{"task_id":"t1","agent":"deployer","task_description":"digest","status":"paused",
+			"parameters":` + string(parameters) + `}`))
+	})
+	c := newTestClient(t, Config{Environment: srv.URL, StateCacheTTL: time.Minute})
+
+	labels := types.MapValueMust(types.StringType, map[string]attr.Value{"env": types.StringValue("prod")})
+	parameters := types.DynamicValue(types.ObjectValueMust(
+		map[string]attr.Type{
+			"retries": types.NumberType,
+			"dry_run": types.BoolType,
+			"labels":  labels.Type(context.Background()),
+			"targets": types.TupleType{ElemTypes: []attr.Type{types.StringType, types.StringType}},
+		},
+		map[string]attr.Value{
+			"retries": types.NumberValue(big.NewFloat(3)),
+			"dry_run": types.BoolValue(true),
+			"labels":  labels,
+			"targets": types.TupleValueMust(
+				[]attr.Type{types.StringType, types.StringType},
+				[]attr.Value{types.StringValue("eu"), types.StringValue("us")},
+			),
+		},
+	))
+
+	plan := &entities.ScheduledTaskModel{
+		Id:            types.StringValue("t1"),
+		Agent:         types.StringValue("deployer"),
+		Context:       types.StringValue("release-train"),
+		MessageText:   types.StringUnknown(),
+		Description:   types.StringValue("digest"),
+		Enabled:       types.BoolValue(false),
+		Repeat:        types.StringValue("0 9 * * *"),
+		ScheduledTime: types.StringValue(""),
+		Parameters:    parameters,
+	}
+
+	result, err := c.UpdateScheduledTask(context.Background(), plan)
+	if err != nil {
+		t.Fatalf("UpdateScheduledTask() error = %v", err)
+	}
+
+	if sent["status"] != "paused" {
+		t.Errorf("sent status %v, want paused", sent["status"])
+	}
+
+	got, _ := json.Marshal(sent["parameters"])
+	if want := `{"context":"release-train","cron_string":"0 9 * * *","dry_run":true,"labels":{"env":"prod"},` +
+		`"message_text":"digest","retries":3,"targets":["eu","us"]}`; string(got) != want {
+		t.Errorf("sent parameters %s, want %s", got, want)
+	}
+
+	if !result.Parameters.Equal(parameters) {
+		t.Errorf("parameters = %v, want %v", result.Parameters, parameters)
+	}
+
+	if result.Enabled.ValueBool() || result.Context.ValueString() != "release-train" || result.MessageText.ValueString() != "digest" {
+		t.Errorf("enabled = %s, context = %s, message text = %s", result.Enabled, result.Context, result.MessageText)
+	}
+}
+
+func TestScheduledTaskTimezone(t *testing.T) {
+	for _, scheduledTime := range []string{"2030-07-01T09:30:00", "2030-07-01T09:30:00+02:00"} {
+		plan := &entities.ScheduledTaskModel{
+			Agent:         types.StringValue("deployer"),
+			Description:   types.StringValue("digest"),
+			Repeat:        types.StringValue("daily"),
+			ScheduledTime: types.StringValue(scheduledTime),
+			Timezone:      types.StringValue("Europe/Berlin"),
+			Parameters:    types.DynamicUnknown(),
+		}
+
+		data, err := createScheduledTask(plan)
+		if err != nil {
+			t.Fatalf("createScheduledTask(%s) error = %v", scheduledTime, err)
+		}
+
+		// 09:30 in Berlin summer time is 07:30 utc, the time the api evaluates the cron in
+		if want := time.Date(2030, time.July, 1, 7, 30, 0, 0, time.UTC); !data.ScheduledTime.Equal(want) || data.ScheduledTime.Location() != time.UTC {
+			t.Errorf("scheduled time of %s = %s, want %s", scheduledTime, data.ScheduledTime, want)
+		}
+
+		if data.CronString != "30 7 * * * *" {
+			t.Errorf("cron of %s = %q, want the utc time", scheduledTime, data.CronString)
+		}
+	}
+
+	srv, _ := newListServer(t, func(w http.ResponseWriter, _ *http.Request, _ int) {
+		_, _ = w.Write([]byte(`{"task_id":"t1","scheduled_time":"2030-07-01T07:30:00",
+			"parameters":{"context":"deployer","repeat":true,"cron_string":"30 7 * * * *"}}`))
+	})
+	c := newTestClient(t, Config{Environment: srv.URL, StateCacheTTL: time.Minute})
+
+	prior := &entities.ScheduledTaskModel{
+		Repeat:        types.StringValue("daily"),
+		ScheduledTime: types.StringValue("2030-07-01T09:30:00"),
+		Timezone:      types.StringValue("Europe/Berlin"),
+	}
+
+	result, err := c.ReadScheduledTask(context.Background(), "t1", prior)
+	if err != nil {
+		t.Fatalf("ReadScheduledTask() error = %v", err)
+	}
+
+	if !result.ScheduledTime.Equal(prior.ScheduledTime) || !result.Timezone.Equal(prior.Timezone) {
+		t.Errorf("scheduled time = %s in %s, want the configured %s in %s",
+			result.ScheduledTime, result.Timezone, prior.ScheduledTime, prior.Timezone)
+	}
+
+	runs := stringsOf(t, result.UpcomingRuns)
+	if len(runs) != upcomingRuns {
+		t.Fatalf("upcoming runs = %v, want %d", runs, upcomingRuns)
+	}
+
+	for _, run := range runs {
+		at, err := time.Parse(time.RFC3339, run)
+		if err != nil {
+			t.Fatalf("upcoming run %q is not RFC3339", run)
+		}
+
+		if at.UTC().Hour() != 7 || at.UTC().Minute() != 30 || !at.After(time.Now()) {
+			t.Errorf("upcoming run %s is not a future 07:30 utc", run)
+		}
+
+		if _, offset := at.Zone(); offset != 3600 && offset != 7200 {
+			t.Errorf("upcoming run %s is not in Europe/Berlin", run)
+		}
+	}
+}
+
+func stringsOf(t *testing.T, list types.List) []string {
+	t.Helper()
+
+	var result []string
+	for _, element := range list.Elements() {
+		str, ok := element.(types.String)
+		if !ok {
+			t.Fatalf("element %v is not a string", element)
+		}
+		result = append(result, str.ValueString())
+	}
+
+	return result
+}
+
+func TestScheduledTaskDaylightSaving(t *testing.T) {
+	// 09:00 in New York is 14:00 utc until daylight saving time starts on march 9
+	plan := &entities.ScheduledTaskModel{
+		Agent:         types.StringValue("deployer"),
+		Description:   types.StringValue("digest"),
+		Repeat:        types.StringValue("daily"),
+		ScheduledTime: types.StringValue("2025-03-07T09:00:00"),
+		Timezone:      types.StringValue("America/New_York"),
+		Parameters:    types.DynamicUnknown(),
+	}
+
+	data, err := createScheduledTask(plan)
+	if err != nil {
+		t.Fatalf("createScheduledTask() error = %v", err)
+	}
+
+	if data.CronString != "0 14 * * * *" {
+		t.Fatalf("cron = %q, want the utc time of scheduled_time", data.CronString)
+	}
+
+	loc, _ := time.LoadLocation("America/New_York")
+	task := &scheduledTask{Parameters: map[string]any{"repeat": true, "cron_string": data.CronString}}
+
+	runs, err := nextRuns(task, loc, time.Date(2025, time.March, 7, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("nextRuns() error = %v", err)
+	}
+
+	// the preset keeps firing at 14:00 utc, an hour later in local time once the clocks moved
+	want := []string{
+		"2025-03-07T09:00:00-05:00",
+		"2025-03-08T09:00:00-05:00",
+		"2025-03-09T10:00:00-04:00",
+		"2025-03-10T10:00:00-04:00",
+		"2025-03-11T10:00:00-04:00",
+	}
+	if got := stringsOf(t, runs); !slices.Equal(got, want) {
+		t.Errorf("upcoming runs = %v, want %v", got, want)
+	}
+}
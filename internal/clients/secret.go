@@ -100,6 +100,7 @@ func (c *Client) DeleteSecret(ctx context.Context, entity *entities.SecretModel)
 
 		uri := c.uri(fmt.Sprintf(path, entity.Name.ValueString()))
 		resp, err := c.delete(ctx, uri)
+		c.cache.invalidate(secretsKind)
 		if err != nil {
 			return err
 		}
@@ -141,6 +142,7 @@ func (c *Client) UpdateSecret(ctx context.Context, entity *entities.SecretModel)
 			return err
 		}
 		resp, err := c.update(ctx, uri, body)
+		c.cache.invalidate(secretsKind)
 		if err != nil {
 			return err
 		}
@@ -172,6 +174,7 @@ func (c *Client) CreateSecret(ctx context.Context, entity *entities.SecretModel)
 			return nil, err
 		}
 		resp, err := c.create(ctx, uri, body)
+		c.cache.invalidate(secretsKind)
 		if err != nil {
 			return nil, err
 		}
@@ -69,6 +69,7 @@ func (c *Client) DeleteSource(ctx context.Context, e *entities.SourceModel) erro
 		path := format("/api/v1/sources/%s", id)
 
 		_, err := c.delete(ctx, c.uri(path))
+		c.cache.invalidate(sourcesKind)
 		return err
 	}
 
@@ -126,6 +127,7 @@ func (c *Client) CreateSource(ctx context.Context, e *entities.SourceModel) (*en
 		qps := []string{fmt.Sprintf("runner=%s", data.Runner)}
 
 		resp, err := c.create(ctx, uri, body, qps...)
+		c.cache.invalidate(sourcesKind)
 		if err != nil {
 			return nil, err
 		}
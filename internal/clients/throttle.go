@@ -0,0 +1,174 @@
+package clients
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// The limits the provider applies unless configured otherwise. A Config left at
+// zero is not throttled at all.
+const (
+	DefaultRateLimit   = 10
+	DefaultRateBurst   = 20
+	DefaultMaxInFlight = 8
+)
+
+// tokenBucket lets rate calls per second through, and up to burst at once after a quiet spell.
+type tokenBucket struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst <= 0 {
+		burst = int(math.Max(1, math.Ceil(rate)))
+	}
+
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token and returns how long the caller has to wait for it.
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel hands back the token of a reservation the caller gave up waiting for.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
+
+// throttledTransport holds every http attempt, retries included, to the rate
+// limit and the number of calls in flight. The request timeout starts once the
+// attempt got through, so the time spent waiting for a slot does not count.
+type throttledTransport struct {
+	next http.RoundTripper
+
+	// bucket is nil when the rate is not limited, inFlight when the calls in flight are not.
+	bucket   *tokenBucket
+	inFlight chan struct{}
+
+	// timeout bounds each attempt, body included. Zero means no limit.
+	timeout time.Duration
+}
+
+func newThrottledTransport(next http.RoundTripper, cfg Config) http.RoundTripper {
+	if cfg.RateLimit == 0 && cfg.MaxInFlight == 0 && cfg.RequestTimeout == 0 {
+		return next
+	}
+
+	result := &throttledTransport{next: next, timeout: cfg.RequestTimeout}
+
+	if cfg.RateLimit > 0 {
+		result.bucket = newTokenBucket(cfg.RateLimit, cfg.RateBurst)
+	}
+
+	if cfg.MaxInFlight > 0 {
+		result.inFlight = make(chan struct{}, cfg.MaxInFlight)
+	}
+
+	return result
+}
+
+func (t *throttledTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if t.bucket != nil {
+		if wait := t.bucket.reserve(); wait > 0 {
+			tflog.SubsystemDebug(ctx, logSubsystem, "Rate limiting API request", map[string]any{
+				"method":  req.Method,
+				"url":     req.URL.String(),
+				"wait_ms": wait.Milliseconds(),
+			})
+
+			if err := sleep(ctx, wait); err != nil {
+				t.bucket.cancel()
+				return nil, err
+			}
+		}
+	}
+
+	if t.inFlight != nil {
+		select {
+		case t.inFlight <- struct{}{}:
+		default:
+			tflog.SubsystemDebug(ctx, logSubsystem, "Waiting for API requests in flight to complete", map[string]any{
+				"method":        req.Method,
+				"url":           req.URL.String(),
+				"max_in_flight": cap(t.inFlight),
+			})
+
+			select {
+			case t.inFlight <- struct{}{}:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+	}
+
+	cancel := context.CancelFunc(func() {})
+	if t.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, t.timeout)
+		req = req.WithContext(ctx)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		cancel()
+		t.release()
+		return nil, err
+	}
+
+	// the call stays in flight, and its timeout running, until its body is closed
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: sync.OnceFunc(func() {
+		cancel()
+		t.release()
+	})}
+
+	return resp, nil
+}
+
+func (t *throttledTransport) release() {
+	if t.inFlight != nil {
+		<-t.inFlight
+	}
+}
+
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
@@ -0,0 +1,187 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+// syncBuffer collects the logs of concurrent calls, each call logs through a logger of its own.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+func TestTokenBucket(t *testing.T) {
+	b := newTokenBucket(100, 2)
+
+	for i := 0; i < 2; i++ {
+		if wait := b.reserve(); wait != 0 {
+			t.Errorf("call %d within the burst waits %s", i, wait)
+		}
+	}
+
+	wait := b.reserve()
+	if wait <= 0 || wait > 10*time.Millisecond {
+		t.Errorf("the call after the burst waits %s, want up to 10ms", wait)
+	}
+
+	next := b.reserve()
+	if next <= wait {
+		t.Errorf("the next call waits %s, want more than %s", next, wait)
+	}
+
+	// a call that gives up waiting hands its token back to the next one
+	b.cancel()
+	if again := b.reserve(); again > next {
+		t.Errorf("the call after a canceled one waits %s, want up to %s", again, next)
+	}
+}
+
+func TestMaxInFlight(t *testing.T) {
+	var running, peak int32
+	srv, _ := newListServer(t, func(w http.ResponseWriter, _ *http.Request, _ int) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	c := newTestClient(t, Config{Environment: srv.URL, MaxInFlight: 2})
+
+	var output syncBuffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if _, err := c.read(ctx, c.uri("/api/v1/agents")); err != nil {
+				t.Errorf("read() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak := atomic.LoadInt32(&peak); peak != 2 {
+		t.Errorf("%d calls ran at the same time, want 2", peak)
+	}
+
+	if !strings.Contains(output.String(), "Waiting for API requests in flight to complete") {
+		t.Errorf("the throttled calls were not logged:\n%s", output.String())
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	srv, ls := newListServer(t, func(w http.ResponseWriter, _ *http.Request, _ int) {
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	c := newTestClient(t, Config{Environment: srv.URL, RateLimit: 50, RateBurst: 1})
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if _, err := c.read(ctx, c.uri("/api/v1/agents")); err != nil {
+			t.Fatalf("read() error = %v", err)
+		}
+	}
+
+	// one call goes through right away, the other three wait 20ms each
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("4 calls at 50 per second took %s", elapsed)
+	}
+
+	if n := ls.count("GET /api/v1/agents"); n != 4 {
+		t.Errorf("the api saw %d calls, want 4", n)
+	}
+
+	if !strings.Contains(output.String(), "Rate limiting API request") {
+		t.Errorf("the throttled calls were not logged:\n%s", output.String())
+	}
+}
+
+func TestThrottledCallIsCanceled(t *testing.T) {
+	srv, _ := newListServer(t, func(w http.ResponseWriter, _ *http.Request, _ int) {
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	c := newTestClient(t, Config{Environment: srv.URL, RateLimit: 0.5, RateBurst: 1})
+
+	if _, err := c.read(context.Background(), c.uri("/api/v1/agents")); err != nil {
+		t.Fatalf("read() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := c.read(ctx, c.uri("/api/v1/agents")); err == nil {
+		t.Error("read() did not fail once its context expired")
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("the canceled call waited %s for the rate limit", elapsed)
+	}
+}
+
+func TestRequestTimeoutExcludesThrottleWait(t *testing.T) {
+	srv, ls := newListServer(t, func(w http.ResponseWriter, _ *http.Request, _ int) {
+		time.Sleep(40 * time.Millisecond)
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	c := newTestClient(t, Config{Environment: srv.URL, MaxInFlight: 1, RequestTimeout: 100 * time.Millisecond})
+
+	// the last call waits about 120ms for its slot, longer than the request
+	// timeout, but its own attempt takes 40ms
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if _, err := c.read(context.Background(), c.uri("/api/v1/agents")); err != nil {
+				t.Errorf("read() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := ls.count("GET /api/v1/agents"); n != 4 {
+		t.Errorf("the api saw %d calls, want 4 without retries", n)
+	}
+}
@@ -0,0 +1,93 @@
+package clients
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+)
+
+// proxySchemes are the proxy url schemes net/http knows how to talk to.
+var proxySchemes = []string{"http", "https", "socks5"}
+
+// newHTTPClient returns the http client every api call goes through, with the
+// proxy, tls, throttling and timeout settings of cfg applied to its transport.
+func newHTTPClient(cfg Config) (*http.Client, error) {
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// a clone keeps the defaults of net/http, HTTPS_PROXY and NO_PROXY included
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	if len(cfg.ProxyUrl) >= 1 {
+		proxy, err := url.Parse(cfg.ProxyUrl)
+		if err != nil {
+			return nil, eformat("proxy url \"%s\" is not valid. %w", cfg.ProxyUrl, err)
+		}
+
+		if !slices.Contains(proxySchemes, proxy.Scheme) || len(proxy.Host) == 0 {
+			return nil, eformat("proxy url \"%s\" is not valid. use a url such as http://proxy.example.com:3128", cfg.ProxyUrl)
+		}
+
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	// the request timeout is applied by the throttled transport rather than
+	// http.Client.Timeout, which would also count the wait for a throttle slot
+	return &http.Client{Transport: newThrottledTransport(transport, cfg)}, nil
+}
+
+func newTLSConfig(cfg Config) (*tls.Config, error) {
+	result := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if len(cfg.CACertFile) >= 1 && len(cfg.CACertPEM) >= 1 {
+		return nil, eformat("only one of ca cert file and ca cert pem may be set")
+	}
+
+	caCert := []byte(cfg.CACertPEM)
+	if len(cfg.CACertFile) >= 1 {
+		b, err := os.ReadFile(cfg.CACertFile)
+		if err != nil {
+			return nil, eformat("failed to read ca cert file. %w", err)
+		}
+		caCert = b
+	}
+
+	if len(caCert) >= 1 {
+		// the bundle is trusted on top of the system roots, so a proxy doing tls
+		// inspection does not cut off the hosts signed by a public ca
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, eformat("the ca cert does not hold any pem encoded certificate")
+		}
+
+		result.RootCAs = pool
+	}
+
+	if len(cfg.ClientCert) >= 1 || len(cfg.ClientKey) >= 1 {
+		if len(cfg.ClientCert) == 0 || len(cfg.ClientKey) == 0 {
+			return nil, eformat("client cert and client key must be set together")
+		}
+
+		cert, err := tls.X509KeyPair([]byte(cfg.ClientCert), []byte(cfg.ClientKey))
+		if err != nil {
+			return nil, eformat("failed to load the client certificate. %w", err)
+		}
+
+		result.Certificates = []tls.Certificate{cert}
+	}
+
+	return result, nil
+}
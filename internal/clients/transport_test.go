@@ -0,0 +1,184 @@
+package clients
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func certPEM(cert *x509.Certificate) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+}
+
+// newClientCert returns a self signed client certificate and its key, pem encoded.
+func newClientCert(t *testing.T) (*x509.Certificate, string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate() error = %v", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("ParseCertificate() error = %v", err)
+	}
+
+	b, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalECPrivateKey() error = %v", err)
+	}
+
+	return cert, certPEM(cert), string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: b}))
+}
+
+func TestCustomCA(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	file := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(file, []byte(certPEM(srv.Certificate())), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{name: "system roots only", cfg: Config{}, wantErr: true},
+		{name: "ca cert pem", cfg: Config{CACertPEM: certPEM(srv.Certificate())}},
+		{name: "ca cert file", cfg: Config{CACertFile: file}},
+		{name: "insecure skip verify", cfg: Config{InsecureSkipVerify: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			cfg.ApiKey = "test-key"
+			cfg.Environment = srv.URL
+
+			c, err := New(cfg)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			_, err = c.read(context.Background(), c.uri("/api/v1/users/self"))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("read() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClientCertificate(t *testing.T) {
+	cert, certPem, keyPem := newClientCert(t)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 || r.TLS.PeerCertificates[0].Subject.CommonName != "terraform" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: pool}
+	srv.StartTLS()
+	defer srv.Close()
+
+	c := newTestClient(t, Config{
+		Environment: srv.URL,
+		CACertPEM:   certPEM(srv.Certificate()),
+		ClientCert:  certPem,
+		ClientKey:   keyPem,
+	})
+
+	if _, err := c.read(context.Background(), c.uri("/api/v1/users/self")); err != nil {
+		t.Errorf("read() error = %v", err)
+	}
+}
+
+func TestProxyUrl(t *testing.T) {
+	var host string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// a proxied plain http request carries the absolute url of the target
+		host = r.URL.Host
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer proxy.Close()
+
+	c := newTestClient(t, Config{
+		Environment: "http://api.kubiya.internal",
+		ProxyUrl:    proxy.URL,
+	})
+
+	if _, err := c.read(context.Background(), c.uri("/api/v1/users/self")); err != nil {
+		t.Fatalf("read() error = %v", err)
+	}
+
+	if host != "api.kubiya.internal" {
+		t.Errorf("the proxy saw host %q, want api.kubiya.internal", host)
+	}
+}
+
+func TestInvalidTransportConfig(t *testing.T) {
+	_, certPem, keyPem := newClientCert(t)
+
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{name: "both ca certs", cfg: Config{CACertFile: "ca.pem", CACertPEM: certPem}, want: "only one of"},
+		{name: "missing ca cert file", cfg: Config{CACertFile: filepath.Join(t.TempDir(), "missing.pem")}, want: "failed to read ca cert file"},
+		{name: "no certificate in ca cert", cfg: Config{CACertPEM: "not a certificate"}, want: "does not hold any pem"},
+		{name: "client cert without key", cfg: Config{ClientCert: certPem}, want: "must be set together"},
+		{name: "client key without cert", cfg: Config{ClientKey: keyPem}, want: "must be set together"},
+		{name: "mismatched client key", cfg: Config{ClientCert: certPem, ClientKey: certPem}, want: "failed to load the client certificate"},
+		{name: "proxy without scheme", cfg: Config{ProxyUrl: "proxy.example.com:3128"}, want: "proxy url"},
+		{name: "unsupported proxy scheme", cfg: Config{ProxyUrl: "ftp://proxy.example.com"}, want: "proxy url"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			cfg.ApiKey = "test-key"
+
+			_, err := New(cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("New() error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
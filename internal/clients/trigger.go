@@ -182,8 +182,10 @@ func (c *Client) CreateTrigger(ctx context.Context, entity *entities.TriggerMode
 
 	// Call the workflow creation API
 	createPath := "/api/workflows"
-	host := "https://composer.kubiya.ai"
-	workflowURL := c.uriWithHost(host, createPath)
+	workflowURL, err := c.composerUri(createPath)
+	if err != nil {
+		return nil, err
+	}
 	resp, err := c.create(ctx, workflowURL, io.NopCloser(strings.NewReader(string(workflowBody))))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create workflow: %w", err)
@@ -207,7 +209,11 @@ func (c *Client) CreateTrigger(ctx context.Context, entity *entities.TriggerMode
 	}
 
 	publishPath := format("/api/workflows/%s/publish", workflowResp.Id)
-	publishURL := c.uriWithHost(host, publishPath)
+	publishURL, err := c.composerUri(publishPath)
+	if err != nil {
+		_ = c.deleteWorkflow(ctx, workflowResp.Id)
+		return nil, err
+	}
 	resp, err = c.create(ctx, publishURL, io.NopCloser(strings.NewReader(string(publishBody))))
 	if err != nil {
 		// Try to clean up the created workflow
@@ -229,7 +235,11 @@ func (c *Client) CreateTrigger(ctx context.Context, entity *entities.TriggerMode
 	}
 
 	createTriggerPath := format("/api/workflows/%s/webhook-url", workflowResp.Id)
-	webhookURL := c.uriWithHost(host, createTriggerPath)
+	webhookURL, err := c.composerUri(createTriggerPath)
+	if err != nil {
+		_ = c.deleteWorkflow(ctx, workflowResp.Id)
+		return nil, err
+	}
 	resp, err = c.create(ctx, webhookURL, io.NopCloser(strings.NewReader(string(webhookBody))))
 	if err != nil {
 		// Try to clean up
@@ -273,9 +283,11 @@ func (c *Client) ReadTrigger(ctx context.Context, entity *entities.TriggerModel)
 	}
 
 	// Get workflow details
-	host := "https://composer.kubiya.ai"
 	readPath := format("/api/workflows/%s", workflowId)
-	workflowURL := c.uriWithHost(host, readPath)
+	workflowURL, err := c.composerUri(readPath)
+	if err != nil {
+		return err
+	}
 
 	resp, err := c.read(ctx, workflowURL)
 	if err != nil {
@@ -334,9 +346,11 @@ func (c *Client) UpdateTrigger(ctx context.Context, entity *entities.TriggerMode
 		return fmt.Errorf("failed to marshal workflow request: %w", err)
 	}
 
-	host := "https://composer.kubiya.ai"
 	updatePath := format("/api/workflows/%s", workflowId)
-	workflowURL := c.uriWithHost(host, updatePath)
+	workflowURL, err := c.composerUri(updatePath)
+	if err != nil {
+		return err
+	}
 
 	resp, err := c.update(ctx, workflowURL, io.NopCloser(strings.NewReader(string(workflowBody))))
 	if err != nil {
@@ -372,9 +386,11 @@ func (c *Client) DeleteTrigger(ctx context.Context, entity *entities.TriggerMode
 
 // deleteWorkflow is a helper function to delete a workflow
 func (c *Client) deleteWorkflow(ctx context.Context, workflowId string) error {
-	host := "https://composer.kubiya.ai"
 	deletePath := format("/api/workflows/%s", workflowId)
-	workflowURL := c.uriWithHost(host, deletePath)
+	workflowURL, err := c.composerUri(deletePath)
+	if err != nil {
+		return err
+	}
 	resp, err := c.delete(ctx, workflowURL)
 	if err != nil {
 		return fmt.Errorf("failed to delete workflow: %w", err)
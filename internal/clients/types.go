@@ -55,4 +55,5 @@ type state struct {
 	knowledgeList         []*knowledge
 	integrationList       []*integration
 	externalKnowledgeList []*vendors.BaseExternalKnowledge
+	channelList           []*slackChannel
 }
@@ -0,0 +1,195 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-kubiya/internal/entities"
+)
+
+// roleNames flattens the roles of a user, which the api returns either as names or as objects.
+func roleNames(roles []any) []string {
+	result := make([]string, 0, len(roles))
+
+	for _, role := range roles {
+		switch r := role.(type) {
+		case string:
+			result = append(result, r)
+		case map[string]any:
+			if name, ok := r["name"].(string); ok {
+				result = append(result, name)
+			}
+		default:
+			if b, err := json.Marshal(r); err == nil {
+				result = append(result, string(b))
+			}
+		}
+	}
+
+	return result
+}
+
+func fromUser(u *user, cs *state) (*entities.UserModel, error) {
+	var err error
+
+	groupNames := make([]string, 0, len(u.Groups))
+	for _, id := range u.Groups {
+		for _, g := range cs.groupList {
+			if equal(g.UUID, id) {
+				groupNames = append(groupNames, g.Name)
+				break
+			}
+		}
+	}
+
+	result := &entities.UserModel{
+		Id:        types.StringValue(u.UUID),
+		Name:      types.StringValue(u.Name),
+		Email:     types.StringValue(u.Email),
+		Image:     types.StringValue(u.Image),
+		Roles:     toListStringType(roleNames(u.Roles), err),
+		Groups:    toListStringType(groupNames, err),
+		GroupIds:  toListStringType(u.Groups, err),
+		Active:    types.BoolValue(u.Status),
+		Status:    types.StringValue(u.UserStatus),
+		CreatedAt: types.StringValue(u.CreateAt),
+	}
+
+	return result, err
+}
+
+func fromGroup(g *group) (*entities.GroupModel, error) {
+	var err error
+
+	result := &entities.GroupModel{
+		Id:          types.StringValue(g.UUID),
+		Name:        types.StringValue(g.Name),
+		Roles:       toListStringType(g.Roles, err),
+		System:      types.BoolValue(g.System),
+		CreatedAt:   types.StringValue(g.CreateAt),
+		Description: types.StringValue(g.Description),
+	}
+
+	return result, err
+}
+
+// FindUser returns the only user matching id, email or name, in that order of precedence.
+func (c *Client) FindUser(ctx context.Context, id, email, name string) (*entities.UserModel, error) {
+	cs, err := c.state(ctx, usersKind, groupsKind)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*user
+	for _, u := range cs.userList {
+		switch {
+		case len(id) >= 1:
+			if equal(u.UUID, id) {
+				matches = append(matches, u)
+			}
+		case len(email) >= 1:
+			if equal(u.Email, email) {
+				matches = append(matches, u)
+			}
+		default:
+			if equal(u.Name, name) {
+				matches = append(matches, u)
+			}
+		}
+	}
+
+	key := name
+	if len(id) >= 1 {
+		key = id
+	} else if len(email) >= 1 {
+		key = email
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, eformat("user \"%s\" %w", key, ErrNotFound)
+	case 1:
+		return fromUser(matches[0], cs)
+	}
+
+	return nil, eformat("more than one user matches \"%s\". look it up by id or email instead", key)
+}
+
+// ListUsers returns every user of the organization ordered by email.
+func (c *Client) ListUsers(ctx context.Context) ([]*entities.UserModel, error) {
+	cs, err := c.state(ctx, usersKind, groupsKind)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entities.UserModel, 0, len(cs.userList))
+	for _, u := range cs.userList {
+		entity, err := fromUser(u, cs)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, entity)
+	}
+
+	slices.SortStableFunc(result, func(a, b *entities.UserModel) int {
+		return strings.Compare(a.Email.ValueString(), b.Email.ValueString())
+	})
+
+	return result, nil
+}
+
+// FindGroup returns the group with the given id or, when id is empty, the only group named name.
+func (c *Client) FindGroup(ctx context.Context, id, name string) (*entities.GroupModel, error) {
+	cs, err := c.state(ctx, groupsKind)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*group
+	for _, g := range cs.groupList {
+		if (len(id) >= 1 && equal(g.UUID, id)) || (len(id) == 0 && equal(g.Name, name)) {
+			matches = append(matches, g)
+		}
+	}
+
+	key := name
+	if len(id) >= 1 {
+		key = id
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, eformat("group \"%s\" %w", key, ErrNotFound)
+	case 1:
+		return fromGroup(matches[0])
+	}
+
+	return nil, eformat("more than one group is named \"%s\". look it up by id instead", key)
+}
+
+// ListGroups returns every group of the organization ordered by name.
+func (c *Client) ListGroups(ctx context.Context) ([]*entities.GroupModel, error) {
+	cs, err := c.state(ctx, groupsKind)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entities.GroupModel, 0, len(cs.groupList))
+	for _, g := range cs.groupList {
+		entity, err := fromGroup(g)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, entity)
+	}
+
+	slices.SortStableFunc(result, func(a, b *entities.GroupModel) int {
+		return strings.Compare(a.Name.ValueString(), b.Name.ValueString())
+	})
+
+	return result, nil
+}
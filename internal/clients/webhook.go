@@ -94,6 +94,15 @@ func toWebhook(w *entities.WebhookModel, cs *state) (*webhook, error) {
 
 		destination := w.Destination.ValueString()
 
+		// a slack channel is sent by id, resolved through the slack integration of the org
+		if slackChannelName(method, destination) {
+			id, err := resolveChannel(cs, destination)
+			if err != nil {
+				return nil, err
+			}
+			destination = pound + id
+		}
+
 		if !strings.HasPrefix(destination, pound) {
 			t := strings.TrimPrefix(destination, at)
 			for _, u := range cs.userList {
@@ -173,6 +182,12 @@ func fromWebhook(w *webhook, cs *state) (*entities.WebhookModel, error) {
 	if w.Communication != nil {
 		wh.Method = types.StringValue(w.Communication.Method)
 
+		if slackChannelId(w.Communication.Method, destination) {
+			if ch := findChannel(cs, channelName(destination)); ch != nil {
+				wh.Destination = types.StringValue("#" + ch.Name)
+			}
+		}
+
 		// For teams method, extract team_name from the destination JSON
 		if strings.EqualFold(w.Communication.Method, "teams") &&
 			strings.HasPrefix(w.Communication.Destination, "#{") {
@@ -192,33 +207,126 @@ func fromWebhook(w *webhook, cs *state) (*entities.WebhookModel, error) {
 	return wh, nil
 }
 
-func (c *Client) ReadWebhook(_ context.Context, entity *entities.WebhookModel) error {
+// slackChannelName reports whether the destination of a webhook is a slack channel given by name, e.g. #ops.
+func slackChannelName(method, destination string) bool {
+	return isSlack(method) && strings.HasPrefix(destination, "#") && !entities.IsSlackChannelId(channelName(destination))
+}
+
+// slackChannelId reports whether the destination of a webhook is a slack channel given by id, e.g. #C024BE91L.
+func slackChannelId(method, destination string) bool {
+	return isSlack(method) && strings.HasPrefix(destination, "#") && entities.IsSlackChannelId(channelName(destination))
+}
+
+func isSlack(method string) bool {
+	return len(method) == 0 || equal(method, "slack")
+}
+
+// webhookStateKinds returns the lists toWebhook and fromWebhook resolve for the given model.
+func webhookStateKinds(w *entities.WebhookModel) []stateKind {
+	kinds := []stateKind{agentsKind}
+
+	if destination := w.Destination.ValueString(); len(destination) >= 1 && !strings.HasPrefix(destination, "#") {
+		kinds = append(kinds, usersKind)
+	}
+
+	if slackChannelName(w.Method.ValueString(), w.Destination.ValueString()) {
+		kinds = append(kinds, channelsKind)
+	}
+
+	return kinds
+}
+
+// webhookResponseKinds returns the lists fromWebhook resolves for the given api
+// webhook. A channel configured by its id is not looked up.
+func webhookResponseKinds(w *webhook, configured string) []stateKind {
+	kinds := make([]stateKind, 0)
+
+	if len(w.AgentId) >= 1 {
+		kinds = append(kinds, agentsKind)
+	}
+
+	if c := w.Communication; c != nil && slackChannelId(c.Method, c.Destination) && c.Destination != configured {
+		kinds = append(kinds, channelsKind)
+	}
+
+	return kinds
+}
+
+// ReadWebhook hydrates entity from the webhook with its id.
+func (c *Client) ReadWebhook(ctx context.Context, entity *entities.WebhookModel) error {
 	if entity != nil {
-		cs, err := c.state()
+		id := entity.Id.ValueString()
+		if len(id) == 0 {
+			return fmt.Errorf("webhook id is empty")
+		}
+
+		resp, err := c.read(ctx, c.uri(format("/api/v1/event/%s", id)))
 		if err != nil {
 			return err
 		}
 
-		id := entity.Id.ValueString()
-		name := entity.Name.ValueString()
+		var w *webhook
+		if err = json.NewDecoder(resp).Decode(&w); err != nil {
+			return err
+		}
 
-		for _, w := range cs.webhookList {
-			if equal(w.Id, id) || equal(w.Name, name) {
-				entity, err = fromWebhook(w, cs)
-				if err != nil {
-					return err
-				}
+		if w == nil {
+			return eformat("webhook \"%s\" %w", id, ErrNotFound)
+		}
 
-				break
-			}
+		configured := entity.Destination.ValueString()
+		cs, err := c.state(ctx, webhookResponseKinds(w, configured)...)
+		if err != nil {
+			return err
 		}
 
-		return err
+		result, err := fromWebhook(w, cs)
+		if err != nil {
+			return err
+		}
+
+		// a channel configured by its id stays an id
+		if w.Communication != nil && w.Communication.Destination == configured {
+			result.Destination = entity.Destination
+		}
+
+		*entity = *result
+		return nil
 	}
 
 	return fmt.Errorf("param entity (*entities.WebhookModel) is nil")
 }
 
+// WebhookImportId returns the id of the webhook with the given id or, failing
+// that, of the only webhook named key. Only imports should look webhooks up by name.
+func (c *Client) WebhookImportId(ctx context.Context, key string) (string, error) {
+	_, err := c.read(ctx, c.uri(format("/api/v1/event/%s", key)))
+	if err == nil || !IsNotFound(err) {
+		return key, err
+	}
+
+	list, err := c.state(ctx, webhooksKind)
+	if err != nil {
+		return "", err
+	}
+
+	var ids []string
+	for _, w := range list.webhookList {
+		if equal(w.Name, key) {
+			ids = append(ids, w.Id)
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", eformat("webhook \"%s\" %w", key, ErrNotFound)
+	case 1:
+		return ids[0], nil
+	}
+
+	return "", eformat("more than one webhook is named \"%s\". import it by id instead", key)
+}
+
 func (c *Client) DeleteWebhook(ctx context.Context, entity *entities.WebhookModel) error {
 	if entity != nil {
 		const (
@@ -230,6 +338,7 @@ func (c *Client) DeleteWebhook(ctx context.Context, entity *entities.WebhookMode
 		id := entity.Id.ValueString()
 		uri := c.uri(fmt.Sprintf(path, id))
 		resp, err := c.delete(ctx, uri)
+		c.cache.invalidate(webhooksKind)
 		if err != nil {
 			return err
 		}
@@ -269,7 +378,7 @@ func (c *Client) UpdateWebhook(ctx context.Context, entity *entities.WebhookMode
 			path = "/api/v1/event/%s"
 		)
 
-		cs, err := c.state()
+		cs, err := c.state(ctx, webhookStateKinds(entity)...)
 		if err != nil {
 			return err
 		}
@@ -290,6 +399,7 @@ func (c *Client) UpdateWebhook(ctx context.Context, entity *entities.WebhookMode
 		}
 
 		resp, err := c.update(ctx, uri, body)
+		c.cache.invalidate(webhooksKind)
 		if err != nil {
 			return err
 		}
@@ -316,7 +426,7 @@ func (c *Client) CreateWebhook(ctx context.Context, entity *entities.WebhookMode
 			return nil, fmt.Errorf("workflow or agent is required")
 		}
 
-		cs, err := c.state()
+		cs, err := c.state(ctx, webhookStateKinds(entity)...)
 		if err != nil {
 			return nil, err
 		}
@@ -335,6 +445,7 @@ func (c *Client) CreateWebhook(ctx context.Context, entity *entities.WebhookMode
 		}
 
 		resp, err := c.create(ctx, uri, body)
+		c.cache.invalidate(webhooksKind)
 		if err != nil {
 			return nil, err
 		}
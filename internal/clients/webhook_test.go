@@ -0,0 +1,74 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-kubiya/internal/entities"
+)
+
+func TestReadWebhookHydratesEntity(t *testing.T) {
+	lists := map[string]string{
+		"/api/v1/event/w1": `{"id":"w1","name":"deploys","prompt":"summarize","agent_id":"a1","communication":{"method":"Slack","destination":"#ops"}}`,
+		"/api/v1/agents":   `[{"uuid":"a1","name":"deployer"}]`,
+	}
+	srv, ls := newListServer(t, func(w http.ResponseWriter, r *http.Request, _ int) {
+		body, ok := lists[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		_, _ = w.Write([]byte(body))
+	})
+	c := newTestClient(t, Config{Environment: srv.URL, StateCacheTTL: time.Minute})
+
+	entity := &entities.WebhookModel{Id: types.StringValue("w1"), Name: types.StringValue("other")}
+	if err := c.ReadWebhook(context.Background(), entity); err != nil {
+		t.Fatalf("ReadWebhook() error = %v", err)
+	}
+
+	if entity.Id.ValueString() != "w1" || entity.Name.ValueString() != "deploys" || entity.Prompt.ValueString() != "summarize" ||
+		entity.Agent.ValueString() != "deployer" || entity.Destination.ValueString() != "#ops" {
+		t.Errorf("ReadWebhook() = %+v, want the webhook w1", entity)
+	}
+
+	if n := ls.count("GET /api/v1/event"); n != 0 {
+		t.Errorf("the webhook list was loaded %d times by a read by id", n)
+	}
+
+	if err := c.ReadWebhook(context.Background(), &entities.WebhookModel{Id: types.StringValue("w2")}); !IsNotFound(err) {
+		t.Errorf("ReadWebhook() of a missing webhook error = %v, want a not found error", err)
+	}
+}
+
+func TestWebhookImportId(t *testing.T) {
+	lists := map[string]string{
+		"/api/v1/event/w1": `{"id":"w1","name":"deploys"}`,
+		"/api/v1/event":    `[{"id":"w1","name":"deploys"},{"id":"w2","name":"alerts"},{"id":"w3","name":"Alerts"}]`,
+	}
+	srv, _ := newListServer(t, func(w http.ResponseWriter, r *http.Request, _ int) {
+		body, ok := lists[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		_, _ = w.Write([]byte(body))
+	})
+	c := newTestClient(t, Config{Environment: srv.URL, StateCacheTTL: time.Minute})
+
+	for key, want := range map[string]string{"w1": "w1", "Deploys": "w1"} {
+		if id, err := c.WebhookImportId(context.Background(), key); err != nil || id != want {
+			t.Errorf("WebhookImportId(%q) = %q, %v, want %q", key, id, err, want)
+		}
+	}
+
+	if _, err := c.WebhookImportId(context.Background(), "alerts"); err == nil {
+		t.Error("WebhookImportId() accepted a name shared by two webhooks")
+	}
+
+	if _, err := c.WebhookImportId(context.Background(), "missing"); !IsNotFound(err) {
+		t.Errorf("WebhookImportId() of a missing webhook error = %v, want a not found error", err)
+	}
+}
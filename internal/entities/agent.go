@@ -42,6 +42,8 @@ type AgentModel struct {
 	Tools        types.List     `tfsdk:"tool_sources"`
 	Integrations types.List     `tfsdk:"integrations"`
 	Variables    types.Map      `tfsdk:"environment_variables"`
+
+	Timeouts *TimeoutsModel `tfsdk:"timeouts"`
 }
 
 type StarterModel struct {
@@ -49,9 +51,11 @@ type StarterModel struct {
 	Command string `tfsdk:"command"`
 }
 
+// DefaultModel is the LLM model an agent runs when none is configured.
+const DefaultModel = "gpt-4o"
+
 func AgentSchema() schema.Schema {
 	const (
-		defaultModel = "gpt-4o"
 		defaultImage = "ghcr.io/kubiyabot/kubiya-agent:stable"
 	)
 
@@ -99,7 +103,7 @@ func AgentSchema() schema.Schema {
 				Optional:            true,
 				Computed:            true,
 				Description:         "The LLM model that the agent will run",
-				Default:             stringdefault.StaticString(defaultModel),
+				Default:             stringdefault.StaticString(DefaultModel),
 				MarkdownDescription: "The LLM model used by the agent for its operations",
 			},
 
@@ -152,14 +156,14 @@ func AgentSchema() schema.Schema {
 				Computed:            true,
 				ElementType:         types.StringType,
 				Description:         "A list of users that have access to this agent",
-				MarkdownDescription: "An array of users who have access to this agent",
+				MarkdownDescription: "An array of users who have access to this agent, given by email, name or UUID",
 			},
 			"groups": schema.ListAttribute{
 				Optional:            true,
 				Computed:            true,
 				ElementType:         types.StringType,
 				Description:         "A list of groups that have access to this agent",
-				MarkdownDescription: "An array of groups who have access to this agent",
+				MarkdownDescription: "An array of groups who have access to this agent, given by name or UUID",
 			},
 			"secrets": schema.ListAttribute{
 				Optional:            true,
@@ -201,5 +205,8 @@ func AgentSchema() schema.Schema {
 				MarkdownDescription: "A map of key-value pairs representing environment variables for the agent",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
 	}
 }
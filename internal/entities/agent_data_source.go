@@ -0,0 +1,220 @@
+package entities
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// AgentDataModel is an agent as the data sources expose it, an AgentModel without the resource timeouts.
+type AgentDataModel struct {
+	Id           types.String   `tfsdk:"id"`
+	Owner        types.String   `tfsdk:"owner"`
+	CreatedAt    types.String   `tfsdk:"created_at"`
+	Image        types.String   `tfsdk:"image"`
+	Model        types.String   `tfsdk:"model"`
+	IsDebugMode  types.Bool     `tfsdk:"is_debug_mode"`
+	Name         types.String   `tfsdk:"name"`
+	Runner       types.String   `tfsdk:"runner"`
+	Description  types.String   `tfsdk:"description"`
+	Instructions types.String   `tfsdk:"instructions"`
+	Links        types.List     `tfsdk:"links"`
+	Tasks        []TaskModel    `tfsdk:"tasks"`
+	Users        types.List     `tfsdk:"users"`
+	Groups       types.List     `tfsdk:"groups"`
+	Sources      types.List     `tfsdk:"sources"`
+	Secrets      types.List     `tfsdk:"secrets"`
+	Starters     []StarterModel `tfsdk:"starters"`
+	Tools        types.List     `tfsdk:"tool_sources"`
+	Integrations types.List     `tfsdk:"integrations"`
+	Variables    types.Map      `tfsdk:"environment_variables"`
+}
+
+type AgentsDataSourceModel struct {
+	// Optional filters
+	Runner    types.String `tfsdk:"runner"`
+	NameRegex types.String `tfsdk:"name_regex"`
+
+	// Computed
+	Agents []AgentDataModel `tfsdk:"agents"`
+}
+
+func agentDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The ID of the agent",
+			MarkdownDescription: "The unique identifier of the agent",
+		},
+		"name": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The name of the agent",
+			MarkdownDescription: "The descriptive name of the agent",
+		},
+		"runner": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The runner of the agent",
+			MarkdownDescription: "The runner used by the agent",
+		},
+		"description": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The description of the agent",
+			MarkdownDescription: "A detailed description of the agent",
+		},
+		"instructions": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The instructions for the agent",
+			MarkdownDescription: "Instructions provided to the agent",
+		},
+		"is_debug_mode": schema.BoolAttribute{
+			Computed:            true,
+			Description:         "Indicate if agent runs with debug mode",
+			MarkdownDescription: "Indicate if agent runs with debug mode",
+		},
+		"image": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The docker image for the agent",
+			MarkdownDescription: "The Docker image used for the agent",
+		},
+		"model": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The LLM model that the agent runs",
+			MarkdownDescription: "The LLM model used by the agent for its operations",
+		},
+		"owner": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The owner of the agent",
+			MarkdownDescription: "The user who created the agent",
+		},
+		"created_at": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The creation time of the agent",
+			MarkdownDescription: "The timestamp when the agent was created",
+		},
+		"links": schema.ListAttribute{
+			Computed:            true,
+			ElementType:         types.StringType,
+			Description:         "A list of links associated with the agent",
+			MarkdownDescription: "An array of links related to the agent",
+		},
+		"tasks": schema.ListAttribute{
+			Computed: true,
+			ElementType: types.ObjectType{
+				AttrTypes: map[string]attr.Type{
+					"name":        types.StringType,
+					"prompt":      types.StringType,
+					"description": types.StringType,
+				},
+			},
+			Description:         "A list of tasks associated with the agent",
+			MarkdownDescription: "An array of tasks related to the agent",
+		},
+		"tool_sources": schema.ListAttribute{
+			Computed:            true,
+			ElementType:         types.StringType,
+			Description:         "A list of tools consumed by the agent",
+			MarkdownDescription: "An array of URL's the agent pulls tools from",
+		},
+		"users": schema.ListAttribute{
+			Computed:            true,
+			ElementType:         types.StringType,
+			Description:         "A list of users that have access to this agent",
+			MarkdownDescription: "An array of users who have access to this agent",
+		},
+		"groups": schema.ListAttribute{
+			Computed:            true,
+			ElementType:         types.StringType,
+			Description:         "A list of groups that have access to this agent",
+			MarkdownDescription: "An array of groups who have access to this agent",
+		},
+		"secrets": schema.ListAttribute{
+			Computed:            true,
+			ElementType:         types.StringType,
+			Description:         "A list of secrets associated with the agent",
+			MarkdownDescription: "An array of secrets related to the agent",
+		},
+		"sources": schema.ListAttribute{
+			Computed:            true,
+			ElementType:         types.StringType,
+			Description:         "A list of sources consumed by the agent",
+			MarkdownDescription: "An array of sources the agent pulls tools from",
+		},
+		"starters": schema.ListAttribute{
+			Computed: true,
+			ElementType: types.ObjectType{
+				AttrTypes: map[string]attr.Type{
+					"name":    types.StringType,
+					"command": types.StringType,
+				},
+			},
+			Description:         "A list of starters associated with the agent",
+			MarkdownDescription: "An array of starters related to the agent",
+		},
+		"integrations": schema.ListAttribute{
+			Computed:            true,
+			ElementType:         types.StringType,
+			Description:         "A list of integrations associated with the agent",
+			MarkdownDescription: "An array of integrations related to the agent",
+		},
+		"environment_variables": schema.MapAttribute{
+			Computed:            true,
+			ElementType:         types.StringType,
+			Description:         "A map of environment variables for the agent",
+			MarkdownDescription: "A map of key-value pairs representing environment variables for the agent",
+		},
+	}
+}
+
+func AgentDataSourceSchema() schema.Schema {
+	attributes := agentDataSourceAttributes()
+
+	attributes["id"] = schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		Description:         "The ID of the agent to look up. Conflicts with name",
+		MarkdownDescription: "The unique identifier of the agent to look up. Exactly one of `id` or `name` must be set",
+	}
+	attributes["name"] = schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		Description:         "The name of the agent to look up. Conflicts with id",
+		MarkdownDescription: "The name of the agent to look up, matched case-insensitively. Exactly one of `id` or `name` must be set",
+	}
+
+	return schema.Schema{
+		Description:         "Looks up a single agent by id or name",
+		MarkdownDescription: "Looks up a single Kubiya agent by `id` or `name`",
+		Attributes:          attributes,
+	}
+}
+
+func AgentsDataSourceSchema() schema.Schema {
+	return schema.Schema{
+		Description:         "Lists the agents of the organization",
+		MarkdownDescription: "Lists the Kubiya agents of the organization, optionally filtered by runner or name",
+		Attributes: map[string]schema.Attribute{
+			"runner": schema.StringAttribute{
+				Optional:            true,
+				Description:         "Only return agents that use this runner",
+				MarkdownDescription: "Only return agents that use this runner",
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:            true,
+				Description:         "Only return agents whose name matches this regular expression",
+				MarkdownDescription: "Only return agents whose name matches this [RE2](https://github.com/google/re2/wiki/Syntax) regular expression",
+				Validators: []validator.String{
+					regexValidator(),
+				},
+			},
+			"agents": schema.ListNestedAttribute{
+				Computed:            true,
+				Description:         "The matching agents",
+				MarkdownDescription: "The matching agents, ordered by name",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: agentDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
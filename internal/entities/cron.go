@@ -0,0 +1,345 @@
+package entities
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchYears bounds the search for the next run of a schedule.
+const cronSearchYears = 10
+
+// cronMacros are the shorthands accepted in place of the five fields.
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	cronMonths = map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}
+	cronWeekdays = map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}
+)
+
+// ExpandCronMacro returns the fields a macro such as @daily stands for, the api
+// only takes fields. Any other expression is returned as is.
+func ExpandCronMacro(expr string) string {
+	if macro, ok := cronMacros[strings.ToLower(strings.TrimSpace(expr))]; ok {
+		return macro
+	}
+
+	return expr
+}
+
+// cronField is one field of a cron expression and the values it accepts.
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+	example  string
+
+	// any lets the field be ? as well as *, i.e. the day fields.
+	any bool
+}
+
+// cronFields are the fields of an expression in order. The year is optional,
+// the schedules the provider generates from a preset carry it.
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31, any: true},
+	{name: "month", min: 1, max: 12, names: cronMonths, example: "JAN"},
+	{name: "day of week", min: 0, max: 7, names: cronWeekdays, example: "MON", any: true},
+	{name: "year", min: 1970, max: 2199},
+}
+
+// cronSet holds the values a field matches.
+type cronSet struct {
+	min    int
+	values []bool
+
+	// star is set when the field is not restricted at all, * or ?.
+	star bool
+
+	// wildcard is set when the field starts with * or ?, */2 included. As in
+	// Vixie cron such a day field does not restrict the other one, see matchesDay.
+	wildcard bool
+}
+
+func (s cronSet) has(value int) bool {
+	i := value - s.min
+	return i >= 0 && i < len(s.values) && s.values[i]
+}
+
+// single returns the value of a field matching exactly one value.
+func (s cronSet) single() (int, bool) {
+	result, count := 0, 0
+	for i, ok := range s.values {
+		if ok {
+			result, count = s.min+i, count+1
+		}
+	}
+
+	return result, count == 1
+}
+
+// cronSchedule is a parsed cron expression.
+type cronSchedule struct {
+	minute  cronSet
+	hour    cronSet
+	day     cronSet
+	month   cronSet
+	weekday cronSet
+	year    cronSet
+}
+
+// parseCronExpression parses a five field cron expression, an optional sixth
+// year field, or one of the @ macros. Every field takes values, names such as
+// MON or JAN, ranges, steps and lists, e.g. "*/15 9-17 * * MON-FRI".
+func parseCronExpression(expr string) (*cronSchedule, error) {
+	fields := strings.Fields(expr)
+
+	if len(fields) == 1 && strings.HasPrefix(fields[0], "@") {
+		macro, ok := cronMacros[strings.ToLower(fields[0])]
+		if !ok {
+			return nil, fmt.Errorf("unknown cron macro %q, use one of @yearly, @monthly, @weekly, @daily or @hourly", fields[0])
+		}
+		fields = strings.Fields(macro)
+	}
+
+	if size := len(fields); size < 5 || size > 6 {
+		return nil, fmt.Errorf("a cron expression has 5 fields, minute hour day-of-month month day-of-week, "+
+			"and an optional year. %q has %d", expr, size)
+	}
+
+	sets := make([]cronSet, len(cronFields))
+	for i, field := range cronFields {
+		value := "*"
+		if i < len(fields) {
+			value = fields[i]
+		}
+
+		set, err := field.parse(value)
+		if err != nil {
+			return nil, err
+		}
+		sets[i] = set
+	}
+
+	// sunday is both 0 and 7
+	if sets[4].has(7) {
+		sets[4].values[0] = true
+	}
+	sets[4].values[7] = false
+
+	return &cronSchedule{
+		minute:  sets[0],
+		hour:    sets[1],
+		day:     sets[2],
+		month:   sets[3],
+		weekday: sets[4],
+		year:    sets[5],
+	}, nil
+}
+
+func (f cronField) parse(value string) (cronSet, error) {
+	result := cronSet{min: f.min, values: make([]bool, f.max-f.min+1)}
+	result.wildcard = strings.HasPrefix(value, "*") || (f.any && strings.HasPrefix(value, "?"))
+
+	if value == "*" || (f.any && value == "?") {
+		result.star = true
+		for i := range result.values {
+			result.values[i] = true
+		}
+		return result, nil
+	}
+
+	for _, item := range strings.Split(value, ",") {
+		if err := f.parseItem(item, result.values); err != nil {
+			return cronSet{}, err
+		}
+	}
+
+	return result, nil
+}
+
+// parseItem sets the values matched by one item of a list: a value, a range, or either with a step.
+func (f cronField) parseItem(item string, values []bool) error {
+	span, step, hasStep := strings.Cut(item, "/")
+
+	increment := 1
+	if hasStep {
+		n, err := strconv.Atoi(step)
+		if err != nil || n <= 0 {
+			return fmt.Errorf("the %s field has an invalid step %q in %q, a step is a positive number", f.name, step, item)
+		}
+		increment = n
+	}
+
+	var first, last int
+	switch {
+	case span == "*" || (f.any && span == "?"):
+		first, last = f.min, f.max
+	case strings.Contains(span, "-"):
+		from, to, _ := strings.Cut(span, "-")
+
+		var err error
+		if first, err = f.value(from); err != nil {
+			return err
+		}
+		if last, err = f.value(to); err != nil {
+			return err
+		}
+
+		if first > last {
+			return fmt.Errorf("the %s field has a reversed range %q, write it from the lower to the higher value", f.name, span)
+		}
+	default:
+		var err error
+		if first, err = f.value(span); err != nil {
+			return err
+		}
+
+		// "5/15" runs from 5 to the end of the field
+		last = first
+		if hasStep {
+			last = f.max
+		}
+	}
+
+	for v := first; v <= last; v += increment {
+		values[v-f.min] = true
+	}
+
+	return nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToUpper(s)]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		if len(f.names) >= 1 {
+			return 0, fmt.Errorf("the %s field has an invalid value %q, use a number between %d and %d or a name such as %s",
+				f.name, s, f.min, f.max, f.example)
+		}
+		return 0, fmt.Errorf("the %s field has an invalid value %q, use a number between %d and %d", f.name, s, f.min, f.max)
+	}
+
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("the %s field value %d is out of range, use a number between %d and %d", f.name, v, f.min, f.max)
+	}
+
+	return v, nil
+}
+
+// preset returns daily, hourly, weekly or monthly when the schedule has the
+// shape the provider generates for that repeat, or "" otherwise.
+func (s *cronSchedule) preset() string {
+	const (
+		daily   = "daily"
+		hourly  = "hourly"
+		weekly  = "weekly"
+		monthly = "monthly"
+	)
+
+	_, minute := s.minute.single()
+	_, hour := s.hour.single()
+	_, day := s.day.single()
+	_, weekday := s.weekday.single()
+
+	if !minute || !s.month.star || !s.year.star {
+		return ""
+	}
+
+	switch {
+	case s.hour.star && s.day.star && s.weekday.star:
+		return hourly
+	case hour && s.day.star && s.weekday.star:
+		return daily
+	case hour && s.day.star && weekday:
+		return weekly
+	case hour && day && s.weekday.star:
+		return monthly
+	}
+
+	return ""
+}
+
+// matchesDay reports whether t falls on a day of the schedule. As in cron, a
+// day matches either day field when both are restricted, and both when one of
+// them starts with *, so 0 0 */2 * MON runs on the odd days that are mondays.
+func (s *cronSchedule) matchesDay(t time.Time) bool {
+	day, weekday := s.day.has(t.Day()), s.weekday.has(int(t.Weekday()))
+
+	if !s.day.wildcard && !s.weekday.wildcard {
+		return day || weekday
+	}
+
+	return day && weekday
+}
+
+// next returns the first time after after the schedule fires, in the location of after.
+func (s *cronSchedule) next(after time.Time) (time.Time, bool) {
+	loc := after.Location()
+	t := after.Truncate(time.Minute).Add(time.Minute)
+
+	// a schedule that fires at all fires within a few years, leap days included
+	end := t.AddDate(cronSearchYears, 0, 0)
+
+	for t.Before(end) {
+		year, month, day := t.Date()
+
+		switch {
+		case !s.year.has(year):
+			t = time.Date(year+1, time.January, 1, 0, 0, 0, 0, loc)
+		case !s.month.has(int(month)):
+			t = time.Date(year, month+1, 1, 0, 0, 0, 0, loc)
+		case !s.matchesDay(t):
+			t = time.Date(year, month, day+1, 0, 0, 0, 0, loc)
+		case !s.hour.has(t.Hour()):
+			t = time.Date(year, month, day, t.Hour()+1, 0, 0, 0, loc)
+		case !s.minute.has(t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// UpcomingCronRuns returns the next n times expr fires after after, evaluated
+// in the location of after. It returns fewer when the schedule ends, e.g.
+// because its year field lies in the past.
+func UpcomingCronRuns(expr string, after time.Time, n int) ([]time.Time, error) {
+	schedule, err := parseCronExpression(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]time.Time, 0, n)
+	for len(result) < n {
+		t, ok := schedule.next(after)
+		if !ok {
+			break
+		}
+
+		result = append(result, t)
+		after = t
+	}
+
+	return result, nil
+}
@@ -0,0 +1,202 @@
+package entities
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func values(s cronSet) []int {
+	var result []int
+	for i, ok := range s.values {
+		if ok {
+			result = append(result, s.min+i)
+		}
+	}
+
+	return result
+}
+
+func TestParseCronExpression(t *testing.T) {
+	tests := []struct {
+		expr  string
+		field func(*cronSchedule) cronSet
+		want  []int
+	}{
+		{expr: "*/15 * * * *", field: func(s *cronSchedule) cronSet { return s.minute }, want: []int{0, 15, 30, 45}},
+		{expr: "0 9-17/4 * * *", field: func(s *cronSchedule) cronSet { return s.hour }, want: []int{9, 13, 17}},
+		{expr: "0 0 1,15 * *", field: func(s *cronSchedule) cronSet { return s.day }, want: []int{1, 15}},
+		{expr: "0 0 * JAN-MAR *", field: func(s *cronSchedule) cronSet { return s.month }, want: []int{1, 2, 3}},
+		{expr: "0 9 * * mon-fri", field: func(s *cronSchedule) cronSet { return s.weekday }, want: []int{1, 2, 3, 4, 5}},
+		{expr: "0 9 * * 5-7", field: func(s *cronSchedule) cronSet { return s.weekday }, want: []int{0, 5, 6}},
+		{expr: "30 2 ? * SUN", field: func(s *cronSchedule) cronSet { return s.weekday }, want: []int{0}},
+		{expr: "5/20 * * * *", field: func(s *cronSchedule) cronSet { return s.minute }, want: []int{5, 25, 45}},
+		{expr: "0 12 * * * 2030", field: func(s *cronSchedule) cronSet { return s.year }, want: []int{2030}},
+		{expr: "@hourly", field: func(s *cronSchedule) cronSet { return s.minute }, want: []int{0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			s, err := parseCronExpression(tt.expr)
+			if err != nil {
+				t.Fatalf("parseCronExpression(%q) error = %v", tt.expr, err)
+			}
+
+			if got := values(tt.field(s)); !slices.Equal(got, tt.want) {
+				t.Errorf("parseCronExpression(%q) matches %v, want %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvalidCronExpression(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{expr: "foo bar baz qux quux", want: `the minute field has an invalid value "foo"`},
+		{expr: "0 9 * *", want: "has 4"},
+		{expr: "0 9 * * * * *", want: "has 7"},
+		{expr: "60 * * * *", want: "the minute field value 60 is out of range"},
+		{expr: "0 24 * * *", want: "the hour field value 24 is out of range"},
+		{expr: "0 0 0 * *", want: "the day of month field value 0 is out of range"},
+		{expr: "0 0 * 13 *", want: "the month field value 13 is out of range"},
+		{expr: "0 0 * * FUN", want: "a name such as MON"},
+		{expr: "0 17-9 * * *", want: "reversed range"},
+		{expr: "*/0 * * * *", want: "invalid step"},
+		{expr: "? * * * *", want: `the minute field has an invalid value "?"`},
+		{expr: "@fortnightly", want: "unknown cron macro"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := parseCronExpression(tt.expr)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("parseCronExpression(%q) error = %v, want it to mention %q", tt.expr, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCronPresets(t *testing.T) {
+	tests := map[string]string{
+		"5 * * * * *":    "hourly",
+		"5 9 * * * *":    "daily",
+		"5 9 * * 1 *":    "weekly",
+		"5 9 12 * * *":   "monthly",
+		"0 9 * * 1-5":    "0 9 * * 1-5",
+		"*/5 * * * *":    "*/5 * * * *",
+		"0 9 * * * 2030": "0 9 * * * 2030",
+		"@daily":         "daily",
+		"@weekly":        "weekly",
+		"@yearly":        "@yearly",
+	}
+
+	for expr, want := range tests {
+		var model ScheduledTaskModel
+		if err := model.ParseCron(expr); err != nil {
+			t.Errorf("ParseCron(%q) error = %v", expr, err)
+			continue
+		}
+
+		if got := model.Repeat.ValueString(); got != want {
+			t.Errorf("ParseCron(%q) repeat = %q, want %q", expr, got, want)
+		}
+	}
+}
+
+func TestCronValidator(t *testing.T) {
+	v := cronValidator("hourly", "daily", "weekly", "monthly")
+
+	for value, valid := range map[string]bool{
+		"":                     true,
+		"daily":                true,
+		"0 9 * * MON-FRI":      true,
+		"@hourly":              true,
+		"foo bar baz qux quux": false,
+		"fortnightly":          false,
+	} {
+		resp := &validator.StringResponse{}
+		v.ValidateString(context.Background(), validator.StringRequest{
+			Path:        path.Root("repeat"),
+			ConfigValue: types.StringValue(value),
+		}, resp)
+
+		if resp.Diagnostics.HasError() == valid {
+			t.Errorf("repeat = %q: diagnostics %v, want valid %t", value, resp.Diagnostics, valid)
+		}
+	}
+}
+
+func TestUpcomingCronRuns(t *testing.T) {
+	// a friday
+	after := time.Date(2025, time.March, 7, 10, 40, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		n    int
+		want []string
+	}{
+		{
+			expr: "*/15 9-10 * * MON-FRI",
+			n:    3,
+			want: []string{"2025-03-07T10:45:00Z", "2025-03-10T09:00:00Z", "2025-03-10T09:15:00Z"},
+		},
+		{
+			expr: "0 0 29 2 *",
+			n:    2,
+			want: []string{"2028-02-29T00:00:00Z", "2032-02-29T00:00:00Z"},
+		},
+		{
+			// either day field matches when both are restricted
+			expr: "0 12 13 * FRI",
+			n:    3,
+			want: []string{"2025-03-07T12:00:00Z", "2025-03-13T12:00:00Z", "2025-03-14T12:00:00Z"},
+		},
+		{
+			// a day field starting with * restricts the other one, as in Vixie cron
+			expr: "0 0 */2 * MON",
+			n:    3,
+			want: []string{"2025-03-17T00:00:00Z", "2025-03-31T00:00:00Z", "2025-04-07T00:00:00Z"},
+		},
+		{
+			expr: "@monthly",
+			n:    2,
+			want: []string{"2025-04-01T00:00:00Z", "2025-05-01T00:00:00Z"},
+		},
+		{
+			expr: "0 9 1 1 * 2026",
+			n:    3,
+			want: []string{"2026-01-01T09:00:00Z"},
+		},
+		{
+			expr: "0 0 30 2 *",
+			n:    1,
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			runs, err := UpcomingCronRuns(tt.expr, after, tt.n)
+			if err != nil {
+				t.Fatalf("UpcomingCronRuns() error = %v", err)
+			}
+
+			got := make([]string, 0, len(runs))
+			for _, run := range runs {
+				got = append(got, run.Format(time.RFC3339))
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("UpcomingCronRuns(%q) = %v, want %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
@@ -19,6 +19,8 @@ type ExternalKnowledgeModel struct {
 	IntegrationType types.String `tfsdk:"integration_type"`
 	CreatedAt       types.String `tfsdk:"created_at"`
 	UpdatedAt       types.String `tfsdk:"updated_at"`
+
+	Timeouts *TimeoutsModel `tfsdk:"timeouts"`
 }
 
 func ExternalKnowledgeSchema() schema.Schema {
@@ -58,5 +60,8 @@ func ExternalKnowledgeSchema() schema.Schema {
 				Description: "The timestamp when the integration was last updated",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
 	}
 }
@@ -15,6 +15,8 @@ type InlineSourceModel struct {
 	Runner    types.String `tfsdk:"runner"`
 	Workflows types.String `tfsdk:"workflows"`
 	Config    types.String `tfsdk:"dynamic_config"`
+
+	Timeouts *TimeoutsModel `tfsdk:"timeouts"`
 }
 
 func InlineSourceSchema() schema.Schema {
@@ -74,5 +76,8 @@ func InlineSourceSchema() schema.Schema {
 				MarkdownDescription: "A map of key-value pairs representing dynamic configuration for the inline source",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
 	}
 }
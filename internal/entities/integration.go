@@ -3,7 +3,9 @@ package entities
 import (
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -14,6 +16,8 @@ type IntegrationModel struct {
 	AuthType    types.String  `tfsdk:"auth_type"`
 	Description types.String  `tfsdk:"description"`
 	Type        types.String  `tfsdk:"integration_type"`
+
+	Timeouts *TimeoutsModel `tfsdk:"timeouts"`
 }
 
 type ConfigModel struct {
@@ -33,7 +37,10 @@ func IntegrationSchema() schema.Schema {
 			"id": schema.StringAttribute{
 				Computed:            true,
 				Description:         "The ID of the integration",
-				MarkdownDescription: "The unique identifier of the integration",
+				MarkdownDescription: "The unique identifier of the integration, its UUID or, when Kubiya doesn't return one, its name",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 
 			// Optional
@@ -78,5 +85,8 @@ func IntegrationSchema() schema.Schema {
 				MarkdownDescription: "The type of the integration (e.g., aws, aws_organization, gcp, azure, jira, confluence)",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
 	}
 }
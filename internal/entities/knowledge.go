@@ -19,6 +19,8 @@ type KnowledgeModel struct {
 
 	Labels          types.List `tfsdk:"labels"`
 	SupportedAgents types.List `tfsdk:"supported_agents"`
+
+	Timeouts *TimeoutsModel `tfsdk:"timeouts"`
 }
 
 func KnowledgeSchema() schema.Schema {
@@ -76,5 +78,8 @@ func KnowledgeSchema() schema.Schema {
 				MarkdownDescription: "An array of agents related to the knowledge",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
 	}
 }
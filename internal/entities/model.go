@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type ModelsDataSourceModel struct {
+	// Computed
+	Default types.String `tfsdk:"default"`
+	Models  types.List   `tfsdk:"models"`
+}
+
+func ModelsDataSourceSchema() schema.Schema {
+	return schema.Schema{
+		Description:         "Lists the LLM models agents can run",
+		MarkdownDescription: "Lists the LLM models a `kubiya_agent` can run in the organization",
+		Attributes: map[string]schema.Attribute{
+			"default": schema.StringAttribute{
+				Computed:            true,
+				Description:         "The model agents run when none is set",
+				MarkdownDescription: "The model a `kubiya_agent` runs when `model` is not set",
+			},
+			"models": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				Description:         "The supported models",
+				MarkdownDescription: "The supported models, in the order the organization lists them",
+			},
+		},
+	}
+}
@@ -6,12 +6,14 @@ import (
 	"encoding/json"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
 	_ planmodifier.String = &jsonStringModifier{}
+	_ planmodifier.String = stateWhenUnchanged{}
 )
 
 type jsonStringModifier struct{}
@@ -76,3 +78,38 @@ func normalizeJSON(input string) (string, error) {
 	// Remove trailing newline added by Encoder
 	return strings.TrimSpace(buf.String()), nil
 }
+
+// stateWhenUnchanged keeps the state of a computed attribute derived from
+// another one, as long as the other attribute keeps its configured value.
+type stateWhenUnchanged struct {
+	other path.Path
+}
+
+func useStateForUnknownWhenUnchanged(other path.Path) planmodifier.String {
+	return stateWhenUnchanged{other: other}
+}
+
+func (m stateWhenUnchanged) Description(_ context.Context) string {
+	return "Keeps the prior value unless " + m.other.String() + " changes"
+}
+
+func (m stateWhenUnchanged) MarkdownDescription(_ context.Context) string {
+	return "Keeps the prior value unless `" + m.other.String() + "` changes"
+}
+
+func (m stateWhenUnchanged) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || !req.PlanValue.IsUnknown() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var config, state types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, m.other, &config)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, m.other, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Equal(state) {
+		resp.PlanValue = req.StateValue
+	}
+}
@@ -0,0 +1,210 @@
+package entities
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type ProviderModel struct {
+	ApiKey      types.String `tfsdk:"api_key"`
+	ApiUrl      types.String `tfsdk:"api_url"`
+	Environment types.String `tfsdk:"environment"`
+	ComposerUrl types.String `tfsdk:"composer_url"`
+
+	MaxRetries   types.Int64  `tfsdk:"max_retries"`
+	RetryWaitMin types.String `tfsdk:"retry_wait_min"`
+	RetryWaitMax types.String `tfsdk:"retry_wait_max"`
+
+	StateCacheTTL types.String `tfsdk:"state_cache_ttl"`
+
+	RequestTimeout types.String `tfsdk:"request_timeout"`
+
+	UserAgentSuffix types.String `tfsdk:"user_agent_suffix"`
+
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	ClientCert         types.String `tfsdk:"client_cert"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	ProxyUrl           types.String `tfsdk:"proxy_url"`
+
+	RateLimit   types.Int64 `tfsdk:"rate_limit"`
+	RateBurst   types.Int64 `tfsdk:"rate_burst"`
+	MaxInFlight types.Int64 `tfsdk:"max_in_flight"`
+
+	Auth *ProviderAuthModel `tfsdk:"auth"`
+}
+
+// ProviderAuthModel is the optional auth block. A nil *ProviderAuthModel means
+// the api key is sent.
+type ProviderAuthModel struct {
+	Type             types.String `tfsdk:"type"`
+	Token            types.String `tfsdk:"token"`
+	TokenFile        types.String `tfsdk:"token_file"`
+	TokenExchangeUrl types.String `tfsdk:"token_exchange_url"`
+}
+
+func ProviderSchema() schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"api_key": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				Description:         "The Kubiya API key. Falls back to KUBIYA_API_KEY",
+				MarkdownDescription: "The Kubiya API key. Falls back to the `KUBIYA_API_KEY` environment variable",
+			},
+			"environment": schema.StringAttribute{
+				Optional:            true,
+				Description:         "The Kubiya environment (production, staging or a base URL). Falls back to KUBIYA_ENV",
+				MarkdownDescription: "The Kubiya environment: `production`, `staging` or a base URL. Falls back to the `KUBIYA_ENV` environment variable and defaults to `production`",
+			},
+			"api_url": schema.StringAttribute{
+				Optional:            true,
+				Description:         "Overrides the Kubiya API base URL. Falls back to KUBIYA_API_URL",
+				MarkdownDescription: "Overrides the Kubiya API base URL derived from `environment`. Falls back to the `KUBIYA_API_URL` environment variable",
+			},
+			"composer_url": schema.StringAttribute{
+				Optional:            true,
+				Description:         "Overrides the Kubiya composer base URL. Falls back to KUBIYA_COMPOSER_URL",
+				MarkdownDescription: "Overrides the Kubiya composer base URL used by `kubiya_trigger`. Falls back to the `KUBIYA_COMPOSER_URL` environment variable",
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:            true,
+				Description:         "How many times a failed API call is retried. Defaults to 3, 0 disables retries",
+				MarkdownDescription: "How many times an API call failing with 429, 502, 503, 504 or a connection error is retried. Defaults to `3`, `0` disables retries",
+				Validators: []validator.Int64{
+					int64AtLeastValidator(0),
+				},
+			},
+			"retry_wait_min": schema.StringAttribute{
+				Optional:            true,
+				Description:         "The initial backoff between retries. Defaults to 1s",
+				MarkdownDescription: "The initial backoff between retries, doubled on every attempt. Defaults to `1s`",
+				Validators: []validator.String{
+					durationValidator(),
+				},
+			},
+			"retry_wait_max": schema.StringAttribute{
+				Optional:            true,
+				Description:         "The maximum backoff between retries. Defaults to 30s",
+				MarkdownDescription: "The maximum backoff between retries. A `Retry-After` header sent by the API takes precedence, capped at this value. Defaults to `30s`",
+				Validators: []validator.String{
+					durationValidator(),
+				},
+			},
+			"state_cache_ttl": schema.StringAttribute{
+				Optional:            true,
+				Description:         "How long org wide lookups (users, groups, runners, ...) are cached. Defaults to 5m, 0s disables the cache",
+				MarkdownDescription: "How long org wide lookups such as users, groups, runners and secrets are cached and shared between resources. Writes made by the provider invalidate the affected lists. Defaults to `5m`, `0s` disables the cache",
+				Validators: []validator.String{
+					durationValidator(),
+				},
+			},
+			"request_timeout": schema.StringAttribute{
+				Optional:            true,
+				Description:         "How long a single API call may take. Defaults to 2m, 0s disables the limit",
+				MarkdownDescription: "How long a single API call may take before it is abandoned. Every retry gets a fresh timeout, and the `timeouts` block of a resource bounds the operation as a whole. Defaults to `2m`, `0s` disables the limit",
+				Validators: []validator.String{
+					durationValidator(),
+				},
+			},
+			"user_agent_suffix": schema.StringAttribute{
+				Optional:            true,
+				Description:         "Appended to the User-Agent header of every API call. Falls back to KUBIYA_USER_AGENT_SUFFIX",
+				MarkdownDescription: "Appended to the `User-Agent` header of every API call, e.g. `ci-pipeline/42`, to attribute the calls in the Kubiya audit logs. Falls back to the `KUBIYA_USER_AGENT_SUFFIX` environment variable",
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional:            true,
+				Description:         "Path to a PEM bundle of CA certificates trusted on top of the system roots. Falls back to KUBIYA_CA_CERT_FILE",
+				MarkdownDescription: "Path to a PEM bundle of CA certificates trusted on top of the system roots, e.g. an internal CA or the CA of a TLS inspecting proxy. Conflicts with `ca_cert_pem`. Falls back to the `KUBIYA_CA_CERT_FILE` environment variable",
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Optional:            true,
+				Description:         "A PEM bundle of CA certificates trusted on top of the system roots",
+				MarkdownDescription: "A PEM bundle of CA certificates trusted on top of the system roots. Conflicts with `ca_cert_file`",
+			},
+			"client_cert": schema.StringAttribute{
+				Optional:            true,
+				Description:         "The PEM encoded client certificate presented for mutual TLS. Requires client_key",
+				MarkdownDescription: "The PEM encoded client certificate presented for mutual TLS, e.g. `file(\"client.crt\")`. Requires `client_key`",
+			},
+			"client_key": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				Description:         "The PEM encoded private key of client_cert",
+				MarkdownDescription: "The PEM encoded private key of `client_cert`",
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Optional:            true,
+				Description:         "Skips the verification of the API certificate. Only meant for testing",
+				MarkdownDescription: "Skips the verification of the API certificate, leaving the API key open to anyone on the network path. Only meant for testing. Defaults to `false`",
+			},
+			"proxy_url": schema.StringAttribute{
+				Optional:            true,
+				Description:         "The proxy every API call goes through. Falls back to KUBIYA_PROXY_URL, then HTTPS_PROXY",
+				MarkdownDescription: "The proxy every API call goes through, e.g. `http://proxy.example.com:3128`. Falls back to the `KUBIYA_PROXY_URL` environment variable, then to the standard `HTTPS_PROXY` and `NO_PROXY` variables",
+			},
+			"rate_limit": schema.Int64Attribute{
+				Optional:            true,
+				Description:         "How many API calls per second the provider makes at most. Defaults to 10, 0 disables the limit",
+				MarkdownDescription: "How many API calls per second the provider makes at most, retries included. Defaults to `10`, `0` disables the limit",
+				Validators: []validator.Int64{
+					int64AtLeastValidator(0),
+				},
+			},
+			"rate_burst": schema.Int64Attribute{
+				Optional:            true,
+				Description:         "How many API calls may start at once after a quiet spell. Defaults to 20",
+				MarkdownDescription: "How many API calls may start at once after a quiet spell, on top of `rate_limit`. Defaults to `20`",
+				Validators: []validator.Int64{
+					int64AtLeastValidator(1),
+				},
+			},
+			"max_in_flight": schema.Int64Attribute{
+				Optional:            true,
+				Description:         "How many API calls may run at the same time. Defaults to 8, 0 disables the limit",
+				MarkdownDescription: "How many API calls may run at the same time, across all the resources Terraform handles in parallel. Defaults to `8`, `0` disables the limit",
+				Validators: []validator.Int64{
+					int64AtLeastValidator(0),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"auth": authBlock(),
+		},
+	}
+}
+
+func authBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description:         "Selects how the provider authenticates. Without it the api_key is sent",
+		MarkdownDescription: "Selects how the provider authenticates. Without it the `api_key` is sent",
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				Required:            true,
+				Description:         "One of api_key, bearer, token_file or oidc",
+				MarkdownDescription: "One of `api_key`, `bearer`, `token_file` or `oidc`",
+				Validators: []validator.String{
+					onOfValidator("type", []string{"api_key", "bearer", "token_file", "oidc"}),
+				},
+			},
+			"token": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				Description:         "The bearer token, or the OIDC token to exchange. Falls back to KUBIYA_TOKEN",
+				MarkdownDescription: "The bearer token with `bearer`, or the OIDC token to exchange with `oidc`. Falls back to the `KUBIYA_TOKEN` environment variable",
+			},
+			"token_file": schema.StringAttribute{
+				Optional:            true,
+				Description:         "The file holding the bearer token or the OIDC token to exchange. Falls back to KUBIYA_TOKEN_FILE",
+				MarkdownDescription: "The file holding the bearer token with `token_file`, or the OIDC token to exchange with `oidc`. It is read again whenever the token expires or the API rejects it. Falls back to the `KUBIYA_TOKEN_FILE` environment variable",
+			},
+			"token_exchange_url": schema.StringAttribute{
+				Optional:            true,
+				Description:         "The RFC 8693 token exchange endpoint trading the OIDC token for a short-lived Kubiya key",
+				MarkdownDescription: "The [RFC 8693](https://www.rfc-editor.org/rfc/rfc8693) token exchange endpoint trading the OIDC token for a short-lived Kubiya key. Required with `oidc`",
+			},
+		},
+	}
+}
@@ -2,19 +2,87 @@ package entities
 
 import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type RunnerModel struct {
-	Name       types.String `tfsdk:"name"`
-	RunnerType types.String `tfsdk:"runner_type"`
+	Name                types.String `tfsdk:"name"`
+	RunnerType          types.String `tfsdk:"runner_type"`
+	Namespace           types.String `tfsdk:"namespace"`
+	Description         types.String `tfsdk:"description"`
+	Version             types.Int64  `tfsdk:"version"`
+	AuthenticationType  types.String `tfsdk:"authentication_type"`
+	WssUrl              types.String `tfsdk:"wss_url"`
+	GatewayUrl          types.String `tfsdk:"gateway_url"`
+	KubernetesNamespace types.String `tfsdk:"kubernetes_namespace"`
+	ManagedBy           types.String `tfsdk:"managed_by"`
+
+	Healthy            types.Bool        `tfsdk:"healthy"`
+	RunnerHealth       RunnerHealthModel `tfsdk:"runner_health"`
+	AgentManagerHealth RunnerHealthModel `tfsdk:"agent_manager_health"`
+	ToolManagerHealth  RunnerHealthModel `tfsdk:"tool_manager_health"`
+
+	InstallManifest types.String `tfsdk:"install_manifest"`
+
+	Timeouts *TimeoutsModel `tfsdk:"timeouts"`
+}
+
+func runnerHealthResourceAttribute(component string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Computed:            true,
+		Description:         "The health reported by the " + component,
+		MarkdownDescription: "The health reported by the " + component + ", as of the last refresh",
+		Attributes: map[string]schema.Attribute{
+			"error":   schema.StringAttribute{Computed: true},
+			"health":  schema.StringAttribute{Computed: true},
+			"status":  schema.StringAttribute{Computed: true},
+			"version": schema.StringAttribute{Computed: true},
+		},
+	}
 }
 
 func RunnerSchema() schema.Schema {
 	return schema.Schema{
 		Attributes: map[string]schema.Attribute{
-			"name":        schema.StringAttribute{Required: true},
-			"runner_type": schema.StringAttribute{Computed: true},
+			"name": schema.StringAttribute{
+				Required:            true,
+				Description:         "The name of the runner",
+				MarkdownDescription: "The name of the runner. Changing it creates a new runner",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"runner_type":          schema.StringAttribute{Computed: true},
+			"namespace":            schema.StringAttribute{Computed: true},
+			"description":          schema.StringAttribute{Computed: true},
+			"version":              schema.Int64Attribute{Computed: true},
+			"authentication_type":  schema.StringAttribute{Computed: true},
+			"wss_url":              schema.StringAttribute{Computed: true},
+			"gateway_url":          schema.StringAttribute{Computed: true},
+			"kubernetes_namespace": schema.StringAttribute{Computed: true},
+			"managed_by":           schema.StringAttribute{Computed: true},
+			"healthy": schema.BoolAttribute{
+				Computed:            true,
+				Description:         "Indicate if the runner and its managers report healthy",
+				MarkdownDescription: "Indicate if the runner, its agent manager and its tool manager all report healthy",
+			},
+			"runner_health":        runnerHealthResourceAttribute("runner"),
+			"agent_manager_health": runnerHealthResourceAttribute("agent manager"),
+			"tool_manager_health":  runnerHealthResourceAttribute("tool manager"),
+			"install_manifest": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				Description:         "The Kubernetes manifest that installs the runner",
+				MarkdownDescription: "The Kubernetes manifest (YAML) that installs the runner into a cluster",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
 		},
 	}
 }
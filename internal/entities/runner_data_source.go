@@ -0,0 +1,176 @@
+package entities
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type RunnerHealthModel struct {
+	Error   types.String `tfsdk:"error"`
+	Health  types.String `tfsdk:"health"`
+	Status  types.String `tfsdk:"status"`
+	Version types.String `tfsdk:"version"`
+}
+
+type RunnerDataModel struct {
+	Name                types.String `tfsdk:"name"`
+	RunnerType          types.String `tfsdk:"runner_type"`
+	Namespace           types.String `tfsdk:"namespace"`
+	Description         types.String `tfsdk:"description"`
+	Version             types.Int64  `tfsdk:"version"`
+	AuthenticationType  types.String `tfsdk:"authentication_type"`
+	WssUrl              types.String `tfsdk:"wss_url"`
+	GatewayUrl          types.String `tfsdk:"gateway_url"`
+	KubernetesNamespace types.String `tfsdk:"kubernetes_namespace"`
+	ManagedBy           types.String `tfsdk:"managed_by"`
+
+	Healthy            types.Bool        `tfsdk:"healthy"`
+	RunnerHealth       RunnerHealthModel `tfsdk:"runner_health"`
+	AgentManagerHealth RunnerHealthModel `tfsdk:"agent_manager_health"`
+	ToolManagerHealth  RunnerHealthModel `tfsdk:"tool_manager_health"`
+}
+
+type RunnersDataSourceModel struct {
+	// Optional filters
+	RunnerType  types.String `tfsdk:"runner_type"`
+	HealthyOnly types.Bool   `tfsdk:"healthy_only"`
+
+	// Computed
+	Runners []RunnerDataModel `tfsdk:"runners"`
+}
+
+func runnerHealthAttribute(component string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Computed:            true,
+		Description:         "The health reported by the " + component,
+		MarkdownDescription: "The health reported by the " + component,
+		Attributes: map[string]schema.Attribute{
+			"error": schema.StringAttribute{
+				Computed:            true,
+				Description:         "The last health check error",
+				MarkdownDescription: "The last health check error, empty when healthy",
+			},
+			"health": schema.StringAttribute{
+				Computed:            true,
+				Description:         "The health of the component",
+				MarkdownDescription: "The health of the component",
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				Description:         "The status of the component",
+				MarkdownDescription: "The status of the component",
+			},
+			"version": schema.StringAttribute{
+				Computed:            true,
+				Description:         "The version of the component",
+				MarkdownDescription: "The version of the component",
+			},
+		},
+	}
+}
+
+func runnerDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The name of the runner",
+			MarkdownDescription: "The name of the runner",
+		},
+		"runner_type": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The type of the runner",
+			MarkdownDescription: "The type of the runner",
+		},
+		"namespace": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The namespace of the runner",
+			MarkdownDescription: "The Kubiya namespace of the runner",
+		},
+		"description": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The description of the runner",
+			MarkdownDescription: "The description of the runner",
+		},
+		"version": schema.Int64Attribute{
+			Computed:            true,
+			Description:         "The version of the runner",
+			MarkdownDescription: "The version of the runner",
+		},
+		"authentication_type": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The authentication type of the runner",
+			MarkdownDescription: "The authentication type the runner uses to connect to Kubiya",
+		},
+		"wss_url": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The websocket URL of the runner",
+			MarkdownDescription: "The websocket URL the runner connects to",
+		},
+		"gateway_url": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The gateway URL of the runner",
+			MarkdownDescription: "The gateway URL of the runner, empty when the runner has no gateway",
+		},
+		"kubernetes_namespace": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The kubernetes namespace of the runner",
+			MarkdownDescription: "The Kubernetes namespace the runner is deployed to",
+		},
+		"managed_by": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The tool that manages the runner",
+			MarkdownDescription: "The tool that manages the runner, e.g. `terraform`",
+		},
+		"healthy": schema.BoolAttribute{
+			Computed:            true,
+			Description:         "Indicate if the runner and its managers report healthy",
+			MarkdownDescription: "Indicate if the runner, its agent manager and its tool manager all report healthy",
+		},
+		"runner_health":        runnerHealthAttribute("runner"),
+		"agent_manager_health": runnerHealthAttribute("agent manager"),
+		"tool_manager_health":  runnerHealthAttribute("tool manager"),
+	}
+}
+
+func RunnerDataSourceSchema() schema.Schema {
+	attributes := runnerDataSourceAttributes()
+
+	attributes["name"] = schema.StringAttribute{
+		Required:            true,
+		Description:         "The name of the runner to look up",
+		MarkdownDescription: "The name of the runner to look up",
+	}
+
+	return schema.Schema{
+		Description:         "Looks up a runner and its health by name",
+		MarkdownDescription: "Looks up a Kubiya runner and its health by name",
+		Attributes:          attributes,
+	}
+}
+
+func RunnersDataSourceSchema() schema.Schema {
+	return schema.Schema{
+		Description:         "Lists the runners of the organization and their health",
+		MarkdownDescription: "Lists the Kubiya runners of the organization and their health",
+		Attributes: map[string]schema.Attribute{
+			"runner_type": schema.StringAttribute{
+				Optional:            true,
+				Description:         "Only return runners of this type",
+				MarkdownDescription: "Only return runners of this type",
+			},
+			"healthy_only": schema.BoolAttribute{
+				Optional:            true,
+				Description:         "Only return healthy runners",
+				MarkdownDescription: "Only return runners whose `healthy` attribute is `true`",
+			},
+			"runners": schema.ListNestedAttribute{
+				Computed:            true,
+				Description:         "The matching runners",
+				MarkdownDescription: "The matching runners, ordered by name",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: runnerDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
@@ -1,8 +1,16 @@
 package entities
 
 import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -11,15 +19,23 @@ type ScheduledTaskModel struct {
 	UUID      types.String `tfsdk:"uuid"`
 	Email     types.String `tfsdk:"email"`
 	Repeat    types.String `tfsdk:"repeat"` // no_repeat, daily, weekly, monthly
+	Channel   types.String `tfsdk:"channel"`
 	ChannelId types.String `tfsdk:"channel_id"`
 
-	Agent             types.String `tfsdk:"agent"`
-	Status            types.String `tfsdk:"status"`
-	TaskType          types.String `tfsdk:"task_type"`
-	Parameters        types.Map    `tfsdk:"parameters"`
-	Description       types.String `tfsdk:"description"`
-	ScheduledTime     types.String `tfsdk:"scheduled_time"`
-	NextScheduledTime types.String `tfsdk:"next_scheduled_time"`
+	Agent             types.String  `tfsdk:"agent"`
+	Status            types.String  `tfsdk:"status"`
+	Enabled           types.Bool    `tfsdk:"enabled"`
+	TaskType          types.String  `tfsdk:"task_type"`
+	Parameters        types.Dynamic `tfsdk:"parameters"`
+	Context           types.String  `tfsdk:"context"`
+	MessageText       types.String  `tfsdk:"message_text"`
+	Description       types.String  `tfsdk:"description"`
+	ScheduledTime     types.String  `tfsdk:"scheduled_time"`
+	NextScheduledTime types.String  `tfsdk:"next_scheduled_time"`
+	Timezone          types.String  `tfsdk:"timezone"`
+	UpcomingRuns      types.List    `tfsdk:"upcoming_runs"`
+
+	Timeouts *TimeoutsModel `tfsdk:"timeouts"`
 }
 
 func ScheduledTaskSchema() schema.Schema {
@@ -33,47 +49,118 @@ func ScheduledTaskSchema() schema.Schema {
 	)
 
 	return schema.Schema{
+		// version 1 added enabled, context and message_text and made parameters dynamic
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			// Computed
 			"id": schema.StringAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"uuid": schema.StringAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"email": schema.StringAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				Description:         "The status of the task as reported by the api, set enabled to pause or resume it",
+				MarkdownDescription: "The status of the task as reported by the API, set `enabled` to pause or resume it",
 			},
 
 			// Required
 			"agent": schema.StringAttribute{
 				Required: true,
-			},
-			"channel_id": schema.StringAttribute{
-				Required: true,
+				// the api can not move a task to another agent
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"description": schema.StringAttribute{
 				Required: true,
 			},
 
+			// One of
+			"channel": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "The name of the Slack channel the task posts to, e.g. #ops. Conflicts with channel_id",
+				MarkdownDescription: "The name of the Slack channel the task posts to, e.g. `#ops`. Conflicts with `channel_id`",
+				PlanModifiers: []planmodifier.String{
+					useStateForUnknownWhenUnchanged(path.Root("channel_id")),
+				},
+			},
+			"channel_id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "The id of the Slack channel the task posts to, e.g. C024BE91L. Conflicts with channel",
+				MarkdownDescription: "The id of the Slack channel the task posts to, e.g. `C024BE91L`. Conflicts with `channel`",
+				PlanModifiers: []planmodifier.String{
+					useStateForUnknownWhenUnchanged(path.Root("channel")),
+				},
+				Validators: []validator.String{
+					channelIdValidator(),
+					exactlyOneOfValidator(path.Root("channel")),
+				},
+			},
+
 			// Optional
 			"repeat": schema.StringAttribute{
 				Computed: true,
 				Optional: true,
 				Default:  stringdefault.StaticString(empty),
+				Validators: []validator.String{
+					cronValidator(hourly, daily, weekly, monthly),
+				},
 			},
-			"status": schema.StringAttribute{
-				Optional: true,
-				Computed: true,
+			"enabled": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				Description:         "Whether the task runs on its schedule, false pauses it",
+				MarkdownDescription: "Whether the task runs on its schedule, `false` pauses it. Defaults to `true`",
+			},
+			"parameters": schema.DynamicAttribute{
+				Computed:            true,
+				Optional:            true,
+				Description:         "Parameters passed to the task, strings, numbers, booleans, lists and objects",
+				MarkdownDescription: "Parameters passed to the task. Values may be strings, numbers, booleans, lists or nested objects",
+			},
+			"context": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "The context the task runs in. Defaults to the agent",
+				MarkdownDescription: "The context the task runs in. Defaults to `agent`",
+				PlanModifiers: []planmodifier.String{
+					useStateForUnknownWhenUnchanged(path.Root("agent")),
+				},
 			},
-			"parameters": schema.MapAttribute{
-				Computed:    true,
-				Optional:    true,
-				ElementType: types.StringType,
+			"message_text": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "The message the task sends to the agent. Defaults to the description",
+				MarkdownDescription: "The message the task sends to the agent. Defaults to `description`",
+				PlanModifiers: []planmodifier.String{
+					useStateForUnknownWhenUnchanged(path.Root("description")),
+				},
 			},
 			"task_type": schema.StringAttribute{
 				Optional: true,
 				Computed: true,
+				// the type is set when the task is created
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"scheduled_time": schema.StringAttribute{
 				Computed: true,
@@ -84,38 +171,154 @@ func ScheduledTaskSchema() schema.Schema {
 				Optional: true,
 				Computed: true,
 			},
+			"timezone": schema.StringAttribute{
+				Optional:            true,
+				Description:         "The IANA time zone scheduled_time is given in. Defaults to UTC, cannot be combined with a cron expression in repeat",
+				MarkdownDescription: "The IANA time zone `scheduled_time` is given in, e.g. `Europe/Berlin`. Defaults to `UTC`. Cannot be combined with a cron expression in `repeat`, which is always evaluated in UTC",
+				Validators: []validator.String{
+					timezoneValidator(),
+					presetsOnlyValidator(path.Root(repeat), hourly, daily, weekly, monthly),
+				},
+			},
+			"upcoming_runs": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				Description:         "The next times the task runs, computed from its schedule",
+				MarkdownDescription: "The next times the task runs as RFC3339 timestamps in `timezone`, computed from its schedule when the task is read",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
 		},
 	}
 }
 
-func (s *ScheduledTaskModel) ParseCron(cronExpr string) error {
-	const (
-		daily   = "daily"
-		hourly  = "hourly"
-		weekly  = "weekly"
-		monthly = "monthly"
-	)
+// ScheduledTaskModelV0 is the state of a task written by schema version 0.
+type ScheduledTaskModelV0 struct {
+	Id        types.String `tfsdk:"id"`
+	UUID      types.String `tfsdk:"uuid"`
+	Email     types.String `tfsdk:"email"`
+	Repeat    types.String `tfsdk:"repeat"`
+	Channel   types.String `tfsdk:"channel"`
+	ChannelId types.String `tfsdk:"channel_id"`
+
+	Agent             types.String `tfsdk:"agent"`
+	Status            types.String `tfsdk:"status"`
+	TaskType          types.String `tfsdk:"task_type"`
+	Parameters        types.Map    `tfsdk:"parameters"`
+	Description       types.String `tfsdk:"description"`
+	ScheduledTime     types.String `tfsdk:"scheduled_time"`
+	NextScheduledTime types.String `tfsdk:"next_scheduled_time"`
+	Timezone          types.String `tfsdk:"timezone"`
+	UpcomingRuns      types.List   `tfsdk:"upcoming_runs"`
+
+	Timeouts *TimeoutsModel `tfsdk:"timeouts"`
+}
 
+// ScheduledTaskSchemaV0 describes the state of schema version 0, where
+// parameters were a map of strings and the status could be configured. It is
+// only used to read old state, keep it as it was released.
+func ScheduledTaskSchemaV0() schema.Schema {
+	timeout := schema.StringAttribute{Optional: true}
+
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":                  schema.StringAttribute{Computed: true},
+			"uuid":                schema.StringAttribute{Computed: true},
+			"email":               schema.StringAttribute{Computed: true},
+			"agent":               schema.StringAttribute{Required: true},
+			"description":         schema.StringAttribute{Required: true},
+			"channel":             schema.StringAttribute{Optional: true, Computed: true},
+			"channel_id":          schema.StringAttribute{Optional: true, Computed: true},
+			"repeat":              schema.StringAttribute{Optional: true, Computed: true},
+			"status":              schema.StringAttribute{Optional: true, Computed: true},
+			"parameters":          schema.MapAttribute{Optional: true, Computed: true, ElementType: types.StringType},
+			"task_type":           schema.StringAttribute{Optional: true, Computed: true},
+			"scheduled_time":      schema.StringAttribute{Optional: true, Computed: true},
+			"next_scheduled_time": schema.StringAttribute{Optional: true, Computed: true},
+			"timezone":            schema.StringAttribute{Optional: true},
+			"upcoming_runs":       schema.ListAttribute{Computed: true, ElementType: types.StringType},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"create": timeout,
+					"read":   timeout,
+					"update": timeout,
+					"delete": timeout,
+				},
+			},
+		},
+	}
+}
+
+// Upgrade returns the version 1 state of a task. The parameters become an object
+// of strings, the way they are usually configured, and a task is enabled unless
+// it is paused. A channel name kept in channel_id, as older releases allowed,
+// moves to channel and the id is read again.
+func (m ScheduledTaskModelV0) Upgrade() ScheduledTaskModel {
+	result := ScheduledTaskModel{
+		Id:                m.Id,
+		UUID:              m.UUID,
+		Email:             m.Email,
+		Repeat:            m.Repeat,
+		Channel:           m.Channel,
+		ChannelId:         m.ChannelId,
+		Agent:             m.Agent,
+		Status:            m.Status,
+		Enabled:           types.BoolValue(!strings.EqualFold(m.Status.ValueString(), "paused")),
+		TaskType:          m.TaskType,
+		Parameters:        types.DynamicNull(),
+		Context:           m.Agent,
+		MessageText:       m.Description,
+		Description:       m.Description,
+		ScheduledTime:     m.ScheduledTime,
+		NextScheduledTime: m.NextScheduledTime,
+		Timezone:          m.Timezone,
+		UpcomingRuns:      m.UpcomingRuns,
+		Timeouts:          m.Timeouts,
+	}
+
+	if name := m.ChannelId.ValueString(); len(name) >= 1 && !IsSlackChannelId(name) && len(m.Channel.ValueString()) == 0 {
+		result.Channel = m.ChannelId
+		result.ChannelId = types.StringNull()
+	}
+
+	if m.Parameters.IsNull() || m.Parameters.IsUnknown() {
+		return result
+	}
+
+	attrTypes := make(map[string]attr.Type, len(m.Parameters.Elements()))
+	for key := range m.Parameters.Elements() {
+		attrTypes[key] = types.StringType
+	}
+	result.Parameters = types.DynamicValue(types.ObjectValueMust(attrTypes, m.Parameters.Elements()))
+
+	if v, ok := m.Parameters.Elements()["context"].(types.String); ok {
+		result.Context = v
+	}
+	if v, ok := m.Parameters.Elements()["message_text"].(types.String); ok {
+		result.MessageText = v
+	}
+
+	return result
+}
+
+// ParseCron sets Repeat from the cron expression of a task: the preset the
+// expression was generated from, or the expression itself.
+func (s *ScheduledTaskModel) ParseCron(cronExpr string) error {
 	if len(cronExpr) <= 0 {
 		return nil
 	}
 
-	err := validCron(cronExpr)
+	schedule, err := parseCronExpression(cronExpr)
 	if err != nil {
 		return err
 	}
 
-	switch {
-	case isDaily(cronExpr):
-		s.Repeat = types.StringValue(daily)
-	case isHourly(cronExpr):
-		s.Repeat = types.StringValue(hourly)
-	case isWeekly(cronExpr):
-		s.Repeat = types.StringValue(weekly)
-	case isMonthly(cronExpr):
-		s.Repeat = types.StringValue(monthly)
-	default:
-		s.Repeat = types.StringValue(cronExpr)
+	s.Repeat = types.StringValue(cronExpr)
+	if preset := schedule.preset(); len(preset) >= 1 {
+		s.Repeat = types.StringValue(preset)
 	}
 
 	return nil
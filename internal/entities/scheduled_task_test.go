@@ -0,0 +1,78 @@
+package entities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestUpgradeScheduledTaskV0(t *testing.T) {
+	v0 := ScheduledTaskModelV0{
+		Agent:       types.StringValue("deployer"),
+		Description: types.StringValue("digest"),
+		Status:      types.StringValue("paused"),
+		Parameters: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"team":    types.StringValue("sre"),
+			"context": types.StringValue("release-train"),
+		}),
+	}
+
+	v1 := v0.Upgrade()
+
+	if v1.Enabled.ValueBool() {
+		t.Error("a paused task was upgraded to an enabled one")
+	}
+
+	if v1.Context.ValueString() != "release-train" || v1.MessageText.ValueString() != "digest" {
+		t.Errorf("context = %s, message text = %s, want release-train and digest", v1.Context, v1.MessageText)
+	}
+
+	// parameters are usually configured as an object literal, the upgrade may not show up as a change
+	want := types.DynamicValue(types.ObjectValueMust(
+		map[string]attr.Type{"team": types.StringType, "context": types.StringType},
+		map[string]attr.Value{"team": types.StringValue("sre"), "context": types.StringValue("release-train")},
+	))
+	if !v1.Parameters.Equal(want) {
+		t.Errorf("parameters = %v, want %v", v1.Parameters, want)
+	}
+
+	if v0.Parameters = types.MapNull(types.StringType); !v0.Upgrade().Parameters.IsNull() {
+		t.Error("parameters that were never set were upgraded to a value")
+	}
+}
+
+func TestTimezoneRejectsCron(t *testing.T) {
+	ctx := context.Background()
+	s := ScheduledTaskSchema()
+	v := s.Attributes["timezone"].(schema.StringAttribute).Validators[1]
+
+	for repeat, valid := range map[string]bool{"": true, "daily": true, "weekly": true, "0 9 * * MON-FRI": false, "@daily": false} {
+		plan := tfsdk.Plan{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+		diags := plan.Set(ctx, &ScheduledTaskModel{
+			Repeat:       types.StringValue(repeat),
+			Timezone:     types.StringValue("Europe/Berlin"),
+			UpcomingRuns: types.ListNull(types.StringType),
+		})
+		if diags.HasError() {
+			t.Fatalf("plan.Set() = %v", diags)
+		}
+
+		resp := &validator.StringResponse{}
+		v.ValidateString(ctx, validator.StringRequest{
+			Path:        path.Root("timezone"),
+			ConfigValue: types.StringValue("Europe/Berlin"),
+			Config:      tfsdk.Config{Schema: s, Raw: plan.Raw},
+		}, resp)
+
+		if resp.Diagnostics.HasError() == valid {
+			t.Errorf("timezone with repeat = %q: diagnostics %v, want valid %t", repeat, resp.Diagnostics, valid)
+		}
+	}
+}
@@ -11,6 +11,8 @@ type SecretModel struct {
 	Description types.String `tfsdk:"description"`
 	CreatedAt   types.String `tfsdk:"created_at"`
 	CreatedBy   types.String `tfsdk:"created_by"`
+
+	Timeouts *TimeoutsModel `tfsdk:"timeouts"`
 }
 
 func SecretSchema() schema.Schema {
@@ -22,5 +24,8 @@ func SecretSchema() schema.Schema {
 			"created_at":  schema.StringAttribute{Computed: true},
 			"created_by":  schema.StringAttribute{Computed: true},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
 	}
 }
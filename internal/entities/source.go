@@ -15,6 +15,8 @@ type SourceModel struct {
 	Name          types.String `tfsdk:"name"`
 	DynamicConfig types.String `tfsdk:"dynamic_config"`
 	Runner        types.String `tfsdk:"runner"`
+
+	Timeouts *TimeoutsModel `tfsdk:"timeouts"`
 }
 
 func SourceSchema() schema.Schema {
@@ -55,5 +57,8 @@ func SourceSchema() schema.Schema {
 				MarkdownDescription: "The runner name to add the source",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
 	}
 }
@@ -0,0 +1,76 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// DefaultTimeout bounds a create, read, update or delete that has no timeout configured.
+const DefaultTimeout = 20 * time.Minute
+
+// TimeoutsModel is the optional timeouts block every resource accepts. A nil
+// *TimeoutsModel means the block is not set.
+type TimeoutsModel struct {
+	Create types.String `tfsdk:"create"`
+	Read   types.String `tfsdk:"read"`
+	Update types.String `tfsdk:"update"`
+	Delete types.String `tfsdk:"delete"`
+}
+
+// Timeout returns the configured timeout of the given operation, or DefaultTimeout.
+func (t *TimeoutsModel) Timeout(operation string) time.Duration {
+	if t == nil {
+		return DefaultTimeout
+	}
+
+	var value types.String
+	switch operation {
+	case "create":
+		value = t.Create
+	case "read":
+		value = t.Read
+	case "update":
+		value = t.Update
+	case "delete":
+		value = t.Delete
+	}
+
+	if value.IsNull() || value.IsUnknown() {
+		return DefaultTimeout
+	}
+
+	// zero and invalid durations are rejected at plan time
+	d, err := time.ParseDuration(value.ValueString())
+	if err != nil || d <= 0 {
+		return DefaultTimeout
+	}
+
+	return d
+}
+
+func timeoutsBlock() schema.SingleNestedBlock {
+	attribute := func(operation string) schema.StringAttribute {
+		return schema.StringAttribute{
+			Optional:            true,
+			Description:         "How long " + operation + " may take, greater than zero. Defaults to 20m",
+			MarkdownDescription: "How long " + operation + " may take, e.g. `30s` or `10m`. Must be greater than zero, defaults to `20m`",
+			Validators: []validator.String{
+				positiveDurationValidator(),
+			},
+		}
+	}
+
+	return schema.SingleNestedBlock{
+		Description:         "Bounds how long each operation on the resource may take",
+		MarkdownDescription: "Bounds how long each operation on the resource may take. The provider `request_timeout` still applies to every single API call",
+		Attributes: map[string]schema.Attribute{
+			"create": attribute("creating the resource"),
+			"read":   attribute("reading the resource"),
+			"update": attribute("updating the resource"),
+			"delete": attribute("deleting the resource"),
+		},
+	}
+}
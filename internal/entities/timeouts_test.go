@@ -0,0 +1,37 @@
+package entities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTimeoutsRejectZero(t *testing.T) {
+	v := timeoutsBlock().Attributes["create"].(schema.StringAttribute).Validators[0]
+
+	for value, valid := range map[string]bool{"30s": true, "10m": true, "0s": false, "0": false, "-1m": false, "soon": false} {
+		resp := &validator.StringResponse{}
+		v.ValidateString(context.Background(), validator.StringRequest{
+			Path:        path.Root("timeouts").AtName("create"),
+			ConfigValue: types.StringValue(value),
+		}, resp)
+
+		if resp.Diagnostics.HasError() == valid {
+			t.Errorf("create = %q: diagnostics %v, want valid %t", value, resp.Diagnostics, valid)
+		}
+	}
+
+	// zero still disables the provider wide limits
+	resp := &validator.StringResponse{}
+	durationValidator().ValidateString(context.Background(), validator.StringRequest{
+		Path:        path.Root("request_timeout"),
+		ConfigValue: types.StringValue("0s"),
+	}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Errorf("request_timeout = \"0s\": diagnostics %v, want valid", resp.Diagnostics)
+	}
+}
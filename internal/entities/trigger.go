@@ -16,6 +16,8 @@ type TriggerModel struct {
 	Url        types.String `tfsdk:"url"`
 	Status     types.String `tfsdk:"status"`
 	WorkflowId types.String `tfsdk:"workflow_id"`
+
+	Timeouts *TimeoutsModel `tfsdk:"timeouts"`
 }
 
 // TriggerSchema defines the schema for the trigger resource.
@@ -67,5 +69,8 @@ func TriggerSchema() schema.Schema {
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
 	}
 }
@@ -0,0 +1,247 @@
+package entities
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type UserModel struct {
+	Id        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Email     types.String `tfsdk:"email"`
+	Image     types.String `tfsdk:"image"`
+	Roles     types.List   `tfsdk:"roles"`
+	Groups    types.List   `tfsdk:"groups"`
+	GroupIds  types.List   `tfsdk:"group_ids"`
+	Active    types.Bool   `tfsdk:"active"`
+	Status    types.String `tfsdk:"status"`
+	CreatedAt types.String `tfsdk:"created_at"`
+}
+
+type UsersDataSourceModel struct {
+	// Optional filters
+	Group      types.String `tfsdk:"group"`
+	EmailRegex types.String `tfsdk:"email_regex"`
+
+	// Computed
+	Users []UserModel `tfsdk:"users"`
+}
+
+type GroupModel struct {
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Roles       types.List   `tfsdk:"roles"`
+	System      types.Bool   `tfsdk:"system"`
+	CreatedAt   types.String `tfsdk:"created_at"`
+	Description types.String `tfsdk:"description"`
+}
+
+type GroupsDataSourceModel struct {
+	// Optional filters
+	NameRegex types.String `tfsdk:"name_regex"`
+
+	// Computed
+	Groups []GroupModel `tfsdk:"groups"`
+}
+
+func userDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The UUID of the user",
+			MarkdownDescription: "The UUID of the user",
+		},
+		"name": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The name of the user",
+			MarkdownDescription: "The display name of the user",
+		},
+		"email": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The email of the user",
+			MarkdownDescription: "The email address of the user",
+		},
+		"image": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The avatar of the user",
+			MarkdownDescription: "The avatar image URL of the user",
+		},
+		"roles": schema.ListAttribute{
+			Computed:            true,
+			ElementType:         types.StringType,
+			Description:         "The roles of the user",
+			MarkdownDescription: "The roles granted to the user",
+		},
+		"groups": schema.ListAttribute{
+			Computed:            true,
+			ElementType:         types.StringType,
+			Description:         "The names of the groups the user belongs to",
+			MarkdownDescription: "The names of the groups the user belongs to",
+		},
+		"group_ids": schema.ListAttribute{
+			Computed:            true,
+			ElementType:         types.StringType,
+			Description:         "The UUIDs of the groups the user belongs to",
+			MarkdownDescription: "The UUIDs of the groups the user belongs to",
+		},
+		"active": schema.BoolAttribute{
+			Computed:            true,
+			Description:         "Indicate if the user is active",
+			MarkdownDescription: "Indicate if the user is active",
+		},
+		"status": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The status of the user",
+			MarkdownDescription: "The status of the user, e.g. `active` or `invited`",
+		},
+		"created_at": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The creation time of the user",
+			MarkdownDescription: "The timestamp when the user was created",
+		},
+	}
+}
+
+func groupDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The UUID of the group",
+			MarkdownDescription: "The UUID of the group",
+		},
+		"name": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The name of the group",
+			MarkdownDescription: "The name of the group",
+		},
+		"roles": schema.ListAttribute{
+			Computed:            true,
+			ElementType:         types.StringType,
+			Description:         "The roles of the group",
+			MarkdownDescription: "The roles granted to the members of the group",
+		},
+		"system": schema.BoolAttribute{
+			Computed:            true,
+			Description:         "Indicate if the group is managed by Kubiya",
+			MarkdownDescription: "Indicate if the group is a built-in group managed by Kubiya",
+		},
+		"created_at": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The creation time of the group",
+			MarkdownDescription: "The timestamp when the group was created",
+		},
+		"description": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The description of the group",
+			MarkdownDescription: "The description of the group",
+		},
+	}
+}
+
+func UserDataSourceSchema() schema.Schema {
+	attributes := userDataSourceAttributes()
+
+	attributes["id"] = schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		Description:         "The UUID of the user to look up",
+		MarkdownDescription: "The UUID of the user to look up. Exactly one of `id`, `email` or `name` must be set",
+	}
+	attributes["email"] = schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		Description:         "The email of the user to look up",
+		MarkdownDescription: "The email of the user to look up, matched case-insensitively. Exactly one of `id`, `email` or `name` must be set",
+	}
+	attributes["name"] = schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		Description:         "The name of the user to look up",
+		MarkdownDescription: "The name of the user to look up, matched case-insensitively. Exactly one of `id`, `email` or `name` must be set",
+	}
+
+	return schema.Schema{
+		Description:         "Looks up a single user by id, email or name",
+		MarkdownDescription: "Looks up a single Kubiya user by `id`, `email` or `name`",
+		Attributes:          attributes,
+	}
+}
+
+func UsersDataSourceSchema() schema.Schema {
+	return schema.Schema{
+		Description:         "Lists the users of the organization",
+		MarkdownDescription: "Lists the Kubiya users of the organization, optionally filtered by group or email",
+		Attributes: map[string]schema.Attribute{
+			"group": schema.StringAttribute{
+				Optional:            true,
+				Description:         "Only return members of this group (name or UUID)",
+				MarkdownDescription: "Only return members of this group, given by name or UUID",
+			},
+			"email_regex": schema.StringAttribute{
+				Optional:            true,
+				Description:         "Only return users whose email matches this regular expression",
+				MarkdownDescription: "Only return users whose email matches this [RE2](https://github.com/google/re2/wiki/Syntax) regular expression",
+				Validators: []validator.String{
+					regexValidator(),
+				},
+			},
+			"users": schema.ListNestedAttribute{
+				Computed:            true,
+				Description:         "The matching users",
+				MarkdownDescription: "The matching users, ordered by email",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: userDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func GroupDataSourceSchema() schema.Schema {
+	attributes := groupDataSourceAttributes()
+
+	attributes["id"] = schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		Description:         "The UUID of the group to look up",
+		MarkdownDescription: "The UUID of the group to look up. Exactly one of `id` or `name` must be set",
+	}
+	attributes["name"] = schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		Description:         "The name of the group to look up",
+		MarkdownDescription: "The name of the group to look up, matched case-insensitively. Exactly one of `id` or `name` must be set",
+	}
+
+	return schema.Schema{
+		Description:         "Looks up a single group by id or name",
+		MarkdownDescription: "Looks up a single Kubiya group by `id` or `name`",
+		Attributes:          attributes,
+	}
+}
+
+func GroupsDataSourceSchema() schema.Schema {
+	return schema.Schema{
+		Description:         "Lists the groups of the organization",
+		MarkdownDescription: "Lists the Kubiya groups of the organization, optionally filtered by name",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Optional:            true,
+				Description:         "Only return groups whose name matches this regular expression",
+				MarkdownDescription: "Only return groups whose name matches this [RE2](https://github.com/google/re2/wiki/Syntax) regular expression",
+				Validators: []validator.String{
+					regexValidator(),
+				},
+			},
+			"groups": schema.ListNestedAttribute{
+				Computed:            true,
+				Description:         "The matching groups",
+				MarkdownDescription: "The matching groups, ordered by name",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: groupDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
@@ -3,12 +3,26 @@ package entities
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"slices"
 	"strings"
+	"time"
+	// the zone database is embedded so time zones resolve on hosts without one, e.g. windows
+	_ "time/tzdata"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// slackChannelId matches the id of a public or private Slack channel, or a direct message.
+var slackChannelId = regexp.MustCompile(`^[CGD][A-Z0-9]{6,}$`)
+
+// IsSlackChannelId reports whether s is a channel id such as C024BE91L rather than a name.
+func IsSlackChannelId(s string) bool {
+	return slackChannelId.MatchString(s)
+}
+
 type stringIsOneOf struct {
 	field string
 	list  []string
@@ -45,3 +59,289 @@ func (v stringIsOneOf) ValidateString(_ context.Context, req validator.StringReq
 		return
 	}
 }
+
+type durationString struct {
+	positive bool
+}
+
+func durationValidator() durationString {
+	return durationString{}
+}
+
+// positiveDurationValidator is a durationValidator that also rejects 0s, for
+// durations where zero has no meaning.
+func positiveDurationValidator() durationString {
+	return durationString{positive: true}
+}
+
+func (v durationString) Description(_ context.Context) string {
+	if v.positive {
+		return "value must be a duration greater than zero such as 500ms, 30s or 2m"
+	}
+	return "value must be a duration such as 500ms, 30s or 2m"
+}
+
+func (v durationString) MarkdownDescription(_ context.Context) string {
+	if v.positive {
+		return "value must be a duration greater than zero such as `500ms`, `30s` or `2m`"
+	}
+	return "value must be a duration such as `500ms`, `30s` or `2m`"
+}
+
+func (v durationString) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("%q is not a valid duration. use a value such as 500ms, 30s or 2m", value),
+		)
+		return
+	}
+
+	if d == 0 && v.positive {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("%q is not allowed, the duration must be greater than zero. remove the attribute to use the default", value),
+		)
+	}
+}
+
+type int64AtLeast struct {
+	min int64
+}
+
+func int64AtLeastValidator(min int64) int64AtLeast {
+	return int64AtLeast{min: min}
+}
+
+func (v int64AtLeast) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be at least %d", v.min)
+}
+
+func (v int64AtLeast) MarkdownDescription(_ context.Context) string {
+	return fmt.Sprintf("value must be at least `%d`", v.min)
+}
+
+func (v int64AtLeast) ValidateInt64(_ context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	if value := req.ConfigValue.ValueInt64(); value < v.min {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Value",
+			fmt.Sprintf("value must be at least %d, got: %d", v.min, value),
+		)
+	}
+}
+
+type regexString struct{}
+
+func regexValidator() regexString {
+	return regexString{}
+}
+
+func (v regexString) Description(_ context.Context) string {
+	return "value must be a valid regular expression"
+}
+
+func (v regexString) MarkdownDescription(_ context.Context) string {
+	return "value must be a valid [RE2](https://github.com/google/re2/wiki/Syntax) regular expression"
+}
+
+func (v regexString) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Regular Expression",
+			err.Error(),
+		)
+	}
+}
+
+type cronString struct {
+	presets []string
+}
+
+// cronValidator accepts the given presets as well as any valid cron expression.
+func cronValidator(presets ...string) cronString {
+	return cronString{presets: append(make([]string, 0), presets...)}
+}
+
+func (v cronString) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be one of %s or a cron expression", strings.Join(v.presets, ", "))
+}
+
+func (v cronString) MarkdownDescription(_ context.Context) string {
+	return fmt.Sprintf("value must be one of `%s` or a cron expression such as `*/15 9-17 * * MON-FRI`", strings.Join(v.presets, "`, `"))
+}
+
+func (v cronString) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if len(value) == 0 || slices.Contains(v.presets, value) {
+		return
+	}
+
+	if _, err := parseCronExpression(value); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Cron Expression",
+			fmt.Sprintf("%q is neither one of %s nor a valid cron expression: %s", value, strings.Join(v.presets, ", "), err),
+		)
+	}
+}
+
+type timezoneString struct{}
+
+func timezoneValidator() timezoneString {
+	return timezoneString{}
+}
+
+func (v timezoneString) Description(_ context.Context) string {
+	return "value must be an IANA time zone such as Europe/Berlin"
+}
+
+func (v timezoneString) MarkdownDescription(_ context.Context) string {
+	return "value must be an [IANA time zone](https://en.wikipedia.org/wiki/List_of_tz_database_time_zones) such as `Europe/Berlin`"
+}
+
+func (v timezoneString) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+
+	if _, err := time.LoadLocation(value); err != nil || len(value) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Time Zone",
+			fmt.Sprintf("%q is not a known time zone. use an IANA name such as UTC, Europe/Berlin or America/New_York", value),
+		)
+	}
+}
+
+// presetsOnly rejects the attribute when the repeat attribute holds a cron
+// expression rather than one of the presets.
+type presetsOnly struct {
+	repeat  path.Path
+	presets []string
+}
+
+func presetsOnlyValidator(repeat path.Path, presets ...string) presetsOnly {
+	return presetsOnly{repeat: repeat, presets: append(make([]string, 0), presets...)}
+}
+
+func (v presetsOnly) Description(_ context.Context) string {
+	return fmt.Sprintf("may only be set when %s is one of %s", v.repeat, strings.Join(v.presets, ", "))
+}
+
+func (v presetsOnly) MarkdownDescription(_ context.Context) string {
+	return fmt.Sprintf("may only be set when `%s` is one of `%s`", v.repeat, strings.Join(v.presets, "`, `"))
+}
+
+func (v presetsOnly) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() {
+		return
+	}
+
+	var repeat types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.repeat, &repeat)...)
+	if resp.Diagnostics.HasError() || repeat.IsNull() || repeat.IsUnknown() {
+		return
+	}
+
+	if value := repeat.ValueString(); len(value) >= 1 && !slices.Contains(v.presets, value) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Conflicting Attributes",
+			fmt.Sprintf("%s cannot be combined with the cron expression %q in %s, the api evaluates cron expressions in UTC. "+
+				"write the expression in UTC and remove %s", req.Path, value, v.repeat, req.Path),
+		)
+	}
+}
+
+type channelIdString struct{}
+
+func channelIdValidator() channelIdString {
+	return channelIdString{}
+}
+
+func (v channelIdString) Description(_ context.Context) string {
+	return "value must be a Slack channel id such as C024BE91L"
+}
+
+func (v channelIdString) MarkdownDescription(_ context.Context) string {
+	return "value must be a Slack channel id such as `C024BE91L`"
+}
+
+func (v channelIdString) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	if value := req.ConfigValue.ValueString(); !IsSlackChannelId(value) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Channel Id",
+			fmt.Sprintf("%q is not a Slack channel id such as C024BE91L. set channel to give the channel by name instead", value),
+		)
+	}
+}
+
+// exactlyOneOf requires either the attribute or the other one to be configured, not both.
+type exactlyOneOf struct {
+	other path.Path
+}
+
+func exactlyOneOfValidator(other path.Path) exactlyOneOf {
+	return exactlyOneOf{other: other}
+}
+
+func (v exactlyOneOf) Description(_ context.Context) string {
+	return fmt.Sprintf("exactly one of this attribute and %s must be set", v.other)
+}
+
+func (v exactlyOneOf) MarkdownDescription(_ context.Context) string {
+	return fmt.Sprintf("exactly one of this attribute and `%s` must be set", v.other)
+}
+
+func (v exactlyOneOf) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	var other types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.other, &other)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch set, otherSet := !req.ConfigValue.IsNull(), !other.IsNull(); {
+	case set && otherSet:
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Conflicting Attributes",
+			fmt.Sprintf("only one of %s and %s may be set", req.Path, v.other),
+		)
+	case !set && !otherSet:
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Missing Attribute",
+			fmt.Sprintf("one of %s and %s must be set", req.Path, v.other),
+		)
+	}
+}
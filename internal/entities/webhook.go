@@ -31,6 +31,8 @@ type WebhookModel struct {
 	Method      types.String `tfsdk:"method"`
 	Runner      types.String `tfsdk:"runner"`
 	Workflow    types.String `tfsdk:"workflow"`
+
+	Timeouts *TimeoutsModel `tfsdk:"timeouts"`
 }
 
 // jsonValidator ensures the provided string is valid JSON.
@@ -289,5 +291,8 @@ func WebhookSchema() schema.Schema {
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
 	}
 }
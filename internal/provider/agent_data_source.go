@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"terraform-provider-kubiya/internal/clients"
+	"terraform-provider-kubiya/internal/entities"
+)
+
+var (
+	_ datasource.DataSource              = (*agentDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*agentDataSource)(nil)
+
+	_ datasource.DataSource              = (*agentsDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*agentsDataSource)(nil)
+)
+
+type agentDataSource struct {
+	name   string
+	client *clients.Client
+}
+
+func NewAgentDataSource() datasource.DataSource {
+	return &agentDataSource{}
+}
+
+func (d *agentDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_agent"
+}
+
+func (d *agentDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = entities.AgentDataSourceSchema()
+}
+
+func (d *agentDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData != nil {
+		var ok bool
+		var client *clients.Client
+
+		if client, ok = req.ProviderData.(*clients.Client); !ok {
+			resp.Diagnostics.AddError(configResourceError(req.ProviderData))
+			return
+		}
+
+		d.name = "agent"
+		d.client = client
+	}
+}
+
+func (d *agentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config entities.AgentDataModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := config.Id.ValueString()
+	name := config.Name.ValueString()
+
+	if (id == "") == (name == "") {
+		resp.Diagnostics.AddAttributeError(path.Root("id"),
+			"Invalid Agent Lookup", "exactly one of id or name must be set")
+		return
+	}
+
+	state, err := d.client.FindAgent(ctx, id, name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			dataSourceReadError(d.name, err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, agentData(state))...)
+}
+
+type agentsDataSource struct {
+	name   string
+	client *clients.Client
+}
+
+func NewAgentsDataSource() datasource.DataSource {
+	return &agentsDataSource{}
+}
+
+func (d *agentsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_agents"
+}
+
+func (d *agentsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = entities.AgentsDataSourceSchema()
+}
+
+func (d *agentsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData != nil {
+		var ok bool
+		var client *clients.Client
+
+		if client, ok = req.ProviderData.(*clients.Client); !ok {
+			resp.Diagnostics.AddError(configResourceError(req.ProviderData))
+			return
+		}
+
+		d.name = "agents"
+		d.client = client
+	}
+}
+
+func (d *agentsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state entities.AgentsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if expr := state.NameRegex.ValueString(); expr != "" {
+		var err error
+		if nameRegex, err = regexp.Compile(expr); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid Regular Expression", err.Error())
+			return
+		}
+	}
+
+	agents, err := d.client.ListAgents(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			dataSourceReadError(d.name, err.Error()),
+		)
+		return
+	}
+
+	runner := state.Runner.ValueString()
+
+	state.Agents = make([]entities.AgentDataModel, 0, len(agents))
+	for _, a := range agents {
+		if runner != "" && !strings.EqualFold(a.Runner.ValueString(), runner) {
+			continue
+		}
+
+		if nameRegex != nil && !nameRegex.MatchString(a.Name.ValueString()) {
+			continue
+		}
+
+		state.Agents = append(state.Agents, agentData(a))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// agentData drops the resource only attributes of an agent.
+func agentData(a *entities.AgentModel) entities.AgentDataModel {
+	return entities.AgentDataModel{
+		Id:           a.Id,
+		Owner:        a.Owner,
+		CreatedAt:    a.CreatedAt,
+		Image:        a.Image,
+		Model:        a.Model,
+		IsDebugMode:  a.IsDebugMode,
+		Name:         a.Name,
+		Runner:       a.Runner,
+		Description:  a.Description,
+		Instructions: a.Instructions,
+		Links:        a.Links,
+		Tasks:        a.Tasks,
+		Users:        a.Users,
+		Groups:       a.Groups,
+		Sources:      a.Sources,
+		Secrets:      a.Secrets,
+		Starters:     a.Starters,
+		Tools:        a.Tools,
+		Integrations: a.Integrations,
+		Variables:    a.Variables,
+	}
+}
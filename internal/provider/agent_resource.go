@@ -2,10 +2,13 @@ package provider
 
 import (
 	"context"
-	"fmt"
+	"slices"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"terraform-provider-kubiya/internal/clients"
 	"terraform-provider-kubiya/internal/entities"
@@ -15,6 +18,7 @@ var (
 	_ resource.Resource                = (*agentResource)(nil)
 	_ resource.ResourceWithConfigure   = (*agentResource)(nil)
 	_ resource.ResourceWithImportState = (*agentResource)(nil)
+	_ resource.ResourceWithModifyPlan  = (*agentResource)(nil)
 )
 
 type agentResource struct {
@@ -35,19 +39,26 @@ func (r *agentResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	timeouts := state.Timeouts
+	ctx, cancel := withTimeout(ctx, timeouts, readAction)
+	defer cancel()
+
 	id := state.Id.ValueString()
 
-	updatedState, err := r.client.ReadAgent(ctx, id)
-	if err != nil || updatedState == nil {
-		if err == nil {
-			err = fmt.Errorf("agent %s not found", id)
+	updatedState, err := r.client.ReadAgent(ctx, id, &state)
+	if err != nil {
+		if clients.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
 		}
+
 		resp.Diagnostics.AddError(
 			resourceActionError(readAction, r.name, err.Error()),
 		)
 		return
 	}
 
+	updatedState.Timeouts = timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, &updatedState)...)
 }
 
@@ -65,6 +76,9 @@ func (r *agentResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, state.Timeouts, deleteAction)
+	defer cancel()
+
 	if err := r.client.DeleteAgent(ctx, &state); err != nil {
 		resp.Diagnostics.AddError(
 			resourceActionError(deleteAction, r.name, err.Error()),
@@ -82,6 +96,9 @@ func (r *agentResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, createAction)
+	defer cancel()
+
 	state, err := r.client.CreateAgent(ctx, &plan)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -90,6 +107,7 @@ func (r *agentResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	state.Timeouts = plan.Timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -103,6 +121,9 @@ func (r *agentResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, updateAction)
+	defer cancel()
+
 	updatedState := state
 
 	if plan.Tasks != nil {
@@ -176,9 +197,136 @@ func (r *agentResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	updatedState.Timeouts = plan.Timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, &updatedState)...)
 }
 
+// agentReference is an agent attribute that refers to other objects. Objects this provider
+// manages may be created earlier in the same apply, so a missing one only warns at plan time.
+type agentReference struct {
+	attribute string
+	kind      string
+	summary   string
+	managed   bool
+}
+
+var agentReferences = []agentReference{
+	{attribute: "runner", kind: "runner", summary: "Unknown Runner", managed: true},
+	{attribute: "users", kind: "user", summary: "Unknown User"},
+	{attribute: "groups", kind: "group", summary: "Unknown Group"},
+	{attribute: "secrets", kind: "secret", summary: "Unknown Secret", managed: true},
+	{attribute: "sources", kind: "source", summary: "Unknown Source", managed: true},
+	{attribute: "integrations", kind: "integration", summary: "Unknown Integration", managed: true},
+}
+
+// ModifyPlan checks the model and the references of the agent at plan time,
+// before any other resource of the apply is created.
+func (r *agentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the agent is destroyed or the provider is not configured yet
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	r.validateModel(ctx, req, resp)
+	r.validateReferences(ctx, req, resp)
+}
+
+func (r *agentResource) validateModel(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var model, prior types.String
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("model"), &model)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("model"), &prior)...)
+	}
+
+	// An unchanged model was accepted before, so a later change of the allowed list doesn't block other updates
+	if resp.Diagnostics.HasError() || model.IsNull() || model.IsUnknown() || model.Equal(prior) {
+		return
+	}
+
+	models, err := r.client.Models(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			resourceActionError(planAction, r.name, err.Error()),
+		)
+		return
+	}
+
+	if !slices.Contains(models, model.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("model"),
+			"Unsupported Model",
+			format("The model \"%s\" is not supported. Allowed values: %s", model.ValueString(), strings.Join(models, ", ")),
+		)
+	}
+}
+
+// validateReferences resolves the known references added by the plan. References kept from
+// the prior state are skipped, the same way an unchanged model is.
+func (r *agentResource) validateReferences(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var refs []clients.AgentReference
+
+	for _, ref := range agentReferences {
+		var planned, prior []attr.Value
+
+		if ref.attribute == "runner" {
+			var value, priorValue types.String
+			resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(ref.attribute), &value)...)
+			if !req.State.Raw.IsNull() {
+				resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(ref.attribute), &priorValue)...)
+			}
+			planned, prior = []attr.Value{value}, []attr.Value{priorValue}
+		} else {
+			var value, priorValue types.List
+			resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(ref.attribute), &value)...)
+			if !req.State.Raw.IsNull() {
+				resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(ref.attribute), &priorValue)...)
+			}
+			planned, prior = value.Elements(), priorValue.Elements()
+		}
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		for i, v := range planned {
+			item, ok := v.(types.String)
+			if !ok || item.IsNull() || item.IsUnknown() || containsFold(prior, item.ValueString()) {
+				continue
+			}
+
+			refs = append(refs, clients.AgentReference{Attribute: ref.attribute, Index: i, Value: item.ValueString()})
+		}
+	}
+
+	missing, err := r.client.MissingAgentReferences(ctx, refs)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			resourceActionError(planAction, r.name, err.Error()),
+		)
+		return
+	}
+
+	for _, m := range missing {
+		ref := agentReferences[slices.IndexFunc(agentReferences, func(ref agentReference) bool {
+			return ref.attribute == m.Attribute
+		})]
+
+		at := path.Root(m.Attribute)
+		if m.Attribute != "runner" {
+			at = at.AtListIndex(m.Index)
+		}
+
+		if ref.managed {
+			resp.Diagnostics.AddAttributeWarning(at, ref.summary,
+				format("The %s \"%s\" doesn't exist. The apply fails unless another resource of this configuration creates it before the agent.", ref.kind, m.Value))
+		} else {
+			resp.Diagnostics.AddAttributeError(at, ref.summary,
+				format("The %s \"%s\" doesn't exist.", ref.kind, m.Value))
+		}
+	}
+}
+
 func (r *agentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_agent"
 }
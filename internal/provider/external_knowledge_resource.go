@@ -2,7 +2,9 @@ package provider
 
 import (
 	"context"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 
 	"terraform-provider-kubiya/internal/clients"
@@ -10,8 +12,9 @@ import (
 )
 
 var (
-	_ resource.Resource              = (*externalKnowledgeResource)(nil)
-	_ resource.ResourceWithConfigure = (*externalKnowledgeResource)(nil)
+	_ resource.Resource                = (*externalKnowledgeResource)(nil)
+	_ resource.ResourceWithConfigure   = (*externalKnowledgeResource)(nil)
+	_ resource.ResourceWithImportState = (*externalKnowledgeResource)(nil)
 )
 
 type externalKnowledgeResource struct {
@@ -32,13 +35,23 @@ func (r *externalKnowledgeResource) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
+	timeouts := state.Timeouts
+	ctx, cancel := withTimeout(ctx, timeouts, readAction)
+	defer cancel()
+
 	if err := r.client.ReadExternalKnowledge(ctx, &state); err != nil {
+		if clients.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			resourceActionError(readAction, r.name, err.Error()),
 		)
 		return
 	}
 
+	state.Timeouts = timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -56,6 +69,9 @@ func (r *externalKnowledgeResource) Delete(ctx context.Context, req resource.Del
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, state.Timeouts, deleteAction)
+	defer cancel()
+
 	if err := r.client.DeleteExternalKnowledge(ctx, &state); err != nil {
 		resp.Diagnostics.AddError(
 			resourceActionError(deleteAction, r.name, err.Error()),
@@ -73,6 +89,9 @@ func (r *externalKnowledgeResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, createAction)
+	defer cancel()
+
 	state, err := r.client.CreateExternalKnowledge(ctx, &plan)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -81,6 +100,7 @@ func (r *externalKnowledgeResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
+	state.Timeouts = plan.Timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -97,6 +117,9 @@ func (r *externalKnowledgeResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, updateAction)
+	defer cancel()
+
 	updatedState := state
 
 	// Update vendor if it has changed
@@ -116,6 +139,7 @@ func (r *externalKnowledgeResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
+	updatedState.Timeouts = plan.Timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, &updatedState)...)
 }
 
@@ -137,3 +161,18 @@ func (r *externalKnowledgeResource) Configure(_ context.Context, req resource.Co
 		r.client = client
 	}
 }
+
+// ImportState accepts the vendor and the uuid of the integration, e.g. slack/<uuid>.
+func (r *externalKnowledgeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	vendor, id, ok := strings.Cut(req.ID, "/")
+	if !ok || vendor == "" || id == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			format("Expected an import identifier of the form vendor/uuid, e.g. slack/<uuid>. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("vendor"), vendor)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
@@ -1,12 +1,18 @@
 package provider
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-kubiya/internal/entities"
+)
 
 const (
 	readAction   = "read"
 	createAction = "create"
 	deleteAction = "delete"
 	updateAction = "update"
+	planAction   = "plan"
 )
 
 func configResourceError(t any) (string, string) {
@@ -30,3 +36,17 @@ func resourceActionError(action, name, err string) (string, string) {
 
 	return format(summary, action, name), format(details, action, name, err)
 }
+
+func dataSourceReadError(name, err string) (string, string) {
+	const (
+		summary = "Failed to read %s data source."
+		details = "Could not read %s data. Error: %s"
+	)
+
+	return format(summary, name), format(details, name, err)
+}
+
+// withTimeout bounds ctx by the timeout the resource configures for action, see entities.TimeoutsModel.
+func withTimeout(ctx context.Context, timeouts *entities.TimeoutsModel, action string) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, timeouts.Timeout(action))
+}
@@ -34,15 +34,25 @@ func (r *inlineSourceResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
+	timeouts := state.Timeouts
+	ctx, cancel := withTimeout(ctx, timeouts, readAction)
+	defer cancel()
+
 	id := state.Id.ValueString()
 	updatedState, err := r.client.ReadInlineSource(ctx, id)
 	if err != nil {
+		if clients.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			resourceActionError(readAction, r.name, err.Error()),
 		)
 		return
 	}
 
+	updatedState.Timeouts = timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, &updatedState)...)
 }
 
@@ -60,6 +70,9 @@ func (r *inlineSourceResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, createAction)
+	defer cancel()
+
 	tools := plan.Tools.ValueString()
 	workflow := plan.Workflows.ValueString()
 
@@ -78,6 +91,7 @@ func (r *inlineSourceResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	state.Timeouts = plan.Timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -91,6 +105,9 @@ func (r *inlineSourceResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, state.Timeouts, deleteAction)
+	defer cancel()
+
 	if err := r.client.DeleteInlineSource(ctx, &state); err != nil {
 		resp.Diagnostics.AddError(
 			resourceActionError(deleteAction, r.name, err.Error()),
@@ -112,6 +129,9 @@ func (r *inlineSourceResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, updateAction)
+	defer cancel()
+
 	// Destroy the current resource
 	if err := r.client.DeleteInlineSource(ctx, &state); err != nil {
 		resp.Diagnostics.AddError(
@@ -129,6 +149,7 @@ func (r *inlineSourceResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
+	newState.Timeouts = plan.Timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
 }
 
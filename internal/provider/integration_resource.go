@@ -34,15 +34,25 @@ func (r *integrationResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	id := state.ID.ValueString()
-	updatedState, err := r.client.ReadIntegration(ctx, id)
+	timeouts := state.Timeouts
+	ctx, cancel := withTimeout(ctx, timeouts, readAction)
+	defer cancel()
+
+	name := state.Name.ValueString()
+	updatedState, err := r.client.ReadIntegration(ctx, name)
 	if err != nil {
+		if clients.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			resourceActionError(readAction, r.name, err.Error()),
 		)
 		return
 	}
 
+	updatedState.Timeouts = timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, &updatedState)...)
 }
 
@@ -60,6 +70,9 @@ func (r *integrationResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, state.Timeouts, deleteAction)
+	defer cancel()
+
 	if err := r.client.DeleteIntegration(ctx, &state); err != nil {
 		resp.Diagnostics.AddError(
 			resourceActionError(deleteAction, r.name, err.Error()),
@@ -77,6 +90,9 @@ func (r *integrationResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, createAction)
+	defer cancel()
+
 	state, err := r.client.CreateIntegration(ctx, &plan)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -85,6 +101,7 @@ func (r *integrationResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	state.Timeouts = plan.Timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -102,6 +119,9 @@ func (r *integrationResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, updateAction)
+	defer cancel()
+
 	updatedState := state
 	updatedState.Configs = plan.Configs
 
@@ -128,6 +148,7 @@ func (r *integrationResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	updatedState.Timeouts = plan.Timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, &updatedState)...)
 }
 
@@ -150,6 +171,7 @@ func (r *integrationResource) Configure(_ context.Context, req resource.Configur
 	}
 }
 
+// ImportState accepts the name of the integration, which the api addresses integrations by.
 func (r *integrationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
 }
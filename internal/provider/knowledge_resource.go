@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 
 	"terraform-provider-kubiya/internal/clients"
@@ -10,8 +11,9 @@ import (
 )
 
 var (
-	_ resource.Resource              = (*knowledgeResource)(nil)
-	_ resource.ResourceWithConfigure = (*knowledgeResource)(nil)
+	_ resource.Resource                = (*knowledgeResource)(nil)
+	_ resource.ResourceWithConfigure   = (*knowledgeResource)(nil)
+	_ resource.ResourceWithImportState = (*knowledgeResource)(nil)
 )
 
 type knowledgeResource struct {
@@ -32,13 +34,23 @@ func (r *knowledgeResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	timeouts := state.Timeouts
+	ctx, cancel := withTimeout(ctx, timeouts, readAction)
+	defer cancel()
+
 	if err := r.client.ReadKnowledge(ctx, &state); err != nil {
+		if clients.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			resourceActionError(readAction, r.name, err.Error()),
 		)
 		return
 	}
 
+	state.Timeouts = timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -56,6 +68,9 @@ func (r *knowledgeResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, state.Timeouts, deleteAction)
+	defer cancel()
+
 	if err := r.client.DeleteKnowledge(ctx, &state); err != nil {
 		resp.Diagnostics.AddError(
 			resourceActionError(deleteAction, r.name, err.Error()),
@@ -73,6 +88,9 @@ func (r *knowledgeResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, createAction)
+	defer cancel()
+
 	state, err := r.client.CreateKnowledge(ctx, &plan)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -81,6 +99,7 @@ func (r *knowledgeResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	state.Timeouts = plan.Timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -97,6 +116,9 @@ func (r *knowledgeResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, updateAction)
+	defer cancel()
+
 	updatedState := state
 
 	if !plan.Id.IsNull() && !plan.Id.IsUnknown() {
@@ -138,6 +160,7 @@ func (r *knowledgeResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	updatedState.Timeouts = plan.Timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, &updatedState)...)
 }
 
@@ -159,3 +182,17 @@ func (r *knowledgeResource) Configure(_ context.Context, req resource.ConfigureR
 		r.client = client
 	}
 }
+
+// ImportState accepts the id or, as a fallback, the unique name of the knowledge.
+func (r *knowledgeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := r.client.KnowledgeImportId(ctx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			format("Could not find the knowledge \"%s\" by id or name. Error: %s", req.ID, err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
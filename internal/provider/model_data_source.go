@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-kubiya/internal/clients"
+	"terraform-provider-kubiya/internal/entities"
+)
+
+var (
+	_ datasource.DataSource              = (*modelsDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*modelsDataSource)(nil)
+)
+
+type modelsDataSource struct {
+	name   string
+	client *clients.Client
+}
+
+func NewModelsDataSource() datasource.DataSource {
+	return &modelsDataSource{}
+}
+
+func (d *modelsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_models"
+}
+
+func (d *modelsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = entities.ModelsDataSourceSchema()
+}
+
+func (d *modelsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData != nil {
+		var ok bool
+		var client *clients.Client
+
+		if client, ok = req.ProviderData.(*clients.Client); !ok {
+			resp.Diagnostics.AddError(configResourceError(req.ProviderData))
+			return
+		}
+
+		d.name = "models"
+		d.client = client
+	}
+}
+
+func (d *modelsDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	models, err := d.client.Models(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			dataSourceReadError(d.name, err.Error()),
+		)
+		return
+	}
+
+	list, diags := types.ListValueFrom(ctx, types.StringType, models)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := entities.ModelsDataSourceModel{
+		Default: types.StringValue(entities.DefaultModel),
+		Models:  list,
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
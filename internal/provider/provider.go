@@ -3,13 +3,16 @@ package provider
 import (
 	"context"
 	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
-	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"terraform-provider-kubiya/internal/clients"
+	"terraform-provider-kubiya/internal/entities"
 )
 
 type kubiyaProvider struct {
@@ -41,40 +44,185 @@ func (p *kubiyaProvider) Resources(context.Context) []func() resource.Resource {
 }
 
 func (p *kubiyaProvider) DataSources(context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewAgentDataSource,
+		NewAgentsDataSource,
+		NewUserDataSource,
+		NewUsersDataSource,
+		NewGroupDataSource,
+		NewGroupsDataSource,
+		NewRunnerDataSource,
+		NewRunnersDataSource,
+		NewModelsDataSource,
+	}
 }
 
 func (p *kubiyaProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
-	resp.Schema = schema.Schema{}
+	resp.Schema = entities.ProviderSchema()
 }
 
 func (p *kubiyaProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "kubiya"
 }
 
-func (p *kubiyaProvider) Configure(_ context.Context, _ provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+func (p *kubiyaProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	const (
 		apiKeyEnvVar         = "KUBIYA_API_KEY"
-		envKeyEnvVar         = "KUBIYA_ENV" // New environment variable for environment selection
+		envKeyEnvVar         = "KUBIYA_ENV"
+		apiUrlEnvVar         = "KUBIYA_API_URL"
+		composerUrlEnvVar    = "KUBIYA_COMPOSER_URL"
+		userAgentEnvVar      = "KUBIYA_USER_AGENT_SUFFIX"
+		caCertFileEnvVar     = "KUBIYA_CA_CERT_FILE"
+		proxyUrlEnvVar       = "KUBIYA_PROXY_URL"
+		tokenEnvVar          = "KUBIYA_TOKEN"
+		tokenFileEnvVar      = "KUBIYA_TOKEN_FILE"
 		missingAPIKey        = "Kubiya API Key Not Configured"
-		missingAPIKeyDetails = "Please set the Kubiya API Key using the environment variable 'KUBIYA_API_KEY'. " +
+		missingAPIKeyDetails = "Please set the Kubiya API Key using the provider attribute 'api_key' " +
+			"or the environment variable 'KUBIYA_API_KEY'. " +
 			"Use the command below:\n> export KUBIYA_API_KEY=YOUR_API_KEY"
+		unknownValue        = "Unknown Kubiya Provider Configuration Value"
+		unknownValueDetails = "The provider cannot create the Kubiya client as there is an unknown configuration value " +
+			"for the attribute '%s'. Set the value statically in the configuration or use the %s environment variable."
+		unknownValueNoEnvDetails = "The provider cannot create the Kubiya client as there is an unknown configuration value " +
+			"for the attribute '%s'. Set the value statically in the configuration."
+		insecure        = "Insecure Kubiya Provider Configuration"
+		insecureDetails = "insecure_skip_verify is set, the certificate of the Kubiya API is not verified. " +
+			"Anyone on the network path can read the API key. Trust the CA with ca_cert_file or ca_cert_pem instead."
 	)
 
-	apiKey := os.Getenv(apiKeyEnvVar)
-	if apiKey == "" {
-		resp.Diagnostics.AddError(missingAPIKey, missingAPIKeyDetails)
+	var config entities.ProviderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	type attribute struct {
+		path   path.Path
+		envVar string
+		value  types.String
+	}
+
+	attributes := []attribute{
+		{path: path.Root("api_key"), envVar: apiKeyEnvVar, value: config.ApiKey},
+		{path: path.Root("environment"), envVar: envKeyEnvVar, value: config.Environment},
+		{path: path.Root("api_url"), envVar: apiUrlEnvVar, value: config.ApiUrl},
+		{path: path.Root("composer_url"), envVar: composerUrlEnvVar, value: config.ComposerUrl},
+		{path: path.Root("user_agent_suffix"), envVar: userAgentEnvVar, value: config.UserAgentSuffix},
+		{path: path.Root("ca_cert_file"), envVar: caCertFileEnvVar, value: config.CACertFile},
+		{path: path.Root("proxy_url"), envVar: proxyUrlEnvVar, value: config.ProxyUrl},
+		{path: path.Root("ca_cert_pem"), value: config.CACertPEM},
+		{path: path.Root("client_cert"), value: config.ClientCert},
+		{path: path.Root("client_key"), value: config.ClientKey},
+	}
+
+	auth := config.Auth
+	if auth != nil {
+		attributes = append(attributes,
+			attribute{path: path.Root("auth").AtName("type"), value: auth.Type},
+			attribute{path: path.Root("auth").AtName("token"), envVar: tokenEnvVar, value: auth.Token},
+			attribute{path: path.Root("auth").AtName("token_file"), envVar: tokenFileEnvVar, value: auth.TokenFile},
+			attribute{path: path.Root("auth").AtName("token_exchange_url"), value: auth.TokenExchangeUrl},
+		)
+	}
+
+	for _, a := range attributes {
+		if !a.value.IsUnknown() {
+			continue
+		}
+
+		if len(a.envVar) == 0 {
+			resp.Diagnostics.AddAttributeError(a.path, unknownValue, format(unknownValueNoEnvDetails, a.path))
+			continue
+		}
+
+		resp.Diagnostics.AddAttributeError(a.path, unknownValue, format(unknownValueDetails, a.path, a.envVar))
+	}
+
+	if config.InsecureSkipVerify.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(path.Root("insecure_skip_verify"), unknownValue, format(unknownValueNoEnvDetails, "insecure_skip_verify"))
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cfg := clients.Config{
+		ApiKey:      valueOrEnv(config.ApiKey, apiKeyEnvVar),
+		ApiUrl:      valueOrEnv(config.ApiUrl, apiUrlEnvVar),
+		Environment: valueOrEnv(config.Environment, envKeyEnvVar),
+		ComposerUrl: valueOrEnv(config.ComposerUrl, composerUrlEnvVar),
+
+		ProviderVersion:  p.version,
+		TerraformVersion: req.TerraformVersion,
+		UserAgentSuffix:  valueOrEnv(config.UserAgentSuffix, userAgentEnvVar),
+
+		CACertFile:         valueOrEnv(config.CACertFile, caCertFileEnvVar),
+		CACertPEM:          config.CACertPEM.ValueString(),
+		ClientCert:         config.ClientCert.ValueString(),
+		ClientKey:          config.ClientKey.ValueString(),
+		InsecureSkipVerify: config.InsecureSkipVerify.ValueBool(),
+		ProxyUrl:           valueOrEnv(config.ProxyUrl, proxyUrlEnvVar),
+	}
+
+	if auth != nil {
+		cfg.Auth = clients.AuthConfig{
+			Type:             auth.Type.ValueString(),
+			Token:            valueOrEnv(auth.Token, tokenEnvVar),
+			TokenFile:        valueOrEnv(auth.TokenFile, tokenFileEnvVar),
+			TokenExchangeUrl: auth.TokenExchangeUrl.ValueString(),
+		}
+	}
+
+	if cfg.InsecureSkipVerify {
+		resp.Diagnostics.AddAttributeWarning(path.Root("insecure_skip_verify"), insecure, insecureDetails)
+	}
+
+	cfg.MaxRetries = clients.DefaultMaxRetries
+	if !config.MaxRetries.IsNull() && !config.MaxRetries.IsUnknown() {
+		cfg.MaxRetries = int(config.MaxRetries.ValueInt64())
+	}
+
+	cfg.RetryWaitMin = durationValue(config.RetryWaitMin)
+	cfg.RetryWaitMax = durationValue(config.RetryWaitMax)
+
+	cfg.StateCacheTTL = clients.DefaultStateCacheTTL
+	if !config.StateCacheTTL.IsNull() && !config.StateCacheTTL.IsUnknown() {
+		cfg.StateCacheTTL = durationValue(config.StateCacheTTL)
+	}
+
+	cfg.RateLimit = clients.DefaultRateLimit
+	if !config.RateLimit.IsNull() && !config.RateLimit.IsUnknown() {
+		cfg.RateLimit = float64(config.RateLimit.ValueInt64())
+	}
+
+	cfg.RateBurst = clients.DefaultRateBurst
+	if !config.RateBurst.IsNull() && !config.RateBurst.IsUnknown() {
+		cfg.RateBurst = int(config.RateBurst.ValueInt64())
+	}
+
+	cfg.MaxInFlight = clients.DefaultMaxInFlight
+	if !config.MaxInFlight.IsNull() && !config.MaxInFlight.IsUnknown() {
+		cfg.MaxInFlight = int(config.MaxInFlight.ValueInt64())
+	}
+
+	cfg.RequestTimeout = clients.DefaultRequestTimeout
+	if !config.RequestTimeout.IsNull() && !config.RequestTimeout.IsUnknown() {
+		cfg.RequestTimeout = durationValue(config.RequestTimeout)
+	}
+
+	// the other auth types are checked by clients.New
+	if (cfg.Auth.Type == "" || cfg.Auth.Type == clients.AuthApiKey) && cfg.ApiKey == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("api_key"), missingAPIKey, missingAPIKeyDetails)
 		return
 	}
 
 	// Fetch the environment or set to default
-	env := os.Getenv(envKeyEnvVar)
-	if env == "" {
-		env = "production"
+	if cfg.Environment == "" {
+		cfg.Environment = "production"
 	}
 
-	// Create a new Kubiya client using the API key and environment
-	client, err := clients.New(apiKey, env)
+	// Create a new Kubiya client using the resolved configuration
+	client, err := clients.New(cfg)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to Create Kubiya Client", "An error occurred while creating the Kubiya client: "+err.Error())
 		return
@@ -84,3 +232,22 @@ func (p *kubiyaProvider) Configure(_ context.Context, _ provider.ConfigureReques
 	resp.ResourceData = client
 	resp.DataSourceData = client
 }
+
+// valueOrEnv returns the configured value, falling back to the environment variable.
+func valueOrEnv(value types.String, envVar string) string {
+	if !value.IsNull() && !value.IsUnknown() && value.ValueString() != "" {
+		return value.ValueString()
+	}
+
+	return os.Getenv(envVar)
+}
+
+// durationValue parses an already validated duration attribute. Null and unknown values yield zero.
+func durationValue(value types.String) time.Duration {
+	if value.IsNull() || value.IsUnknown() {
+		return 0
+	}
+
+	d, _ := time.ParseDuration(value.ValueString())
+	return d
+}
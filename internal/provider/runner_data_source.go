@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+
+	"terraform-provider-kubiya/internal/clients"
+	"terraform-provider-kubiya/internal/entities"
+)
+
+var (
+	_ datasource.DataSource              = (*runnerDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*runnerDataSource)(nil)
+
+	_ datasource.DataSource              = (*runnersDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*runnersDataSource)(nil)
+)
+
+type runnerDataSource struct {
+	name   string
+	client *clients.Client
+}
+
+func NewRunnerDataSource() datasource.DataSource {
+	return &runnerDataSource{}
+}
+
+func (d *runnerDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_runner"
+}
+
+func (d *runnerDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = entities.RunnerDataSourceSchema()
+}
+
+func (d *runnerDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData != nil {
+		var ok bool
+		var client *clients.Client
+
+		if client, ok = req.ProviderData.(*clients.Client); !ok {
+			resp.Diagnostics.AddError(configResourceError(req.ProviderData))
+			return
+		}
+
+		d.name = "runner"
+		d.client = client
+	}
+}
+
+func (d *runnerDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config entities.RunnerDataModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, err := d.client.FindRunner(ctx, config.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			dataSourceReadError(d.name, err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+type runnersDataSource struct {
+	name   string
+	client *clients.Client
+}
+
+func NewRunnersDataSource() datasource.DataSource {
+	return &runnersDataSource{}
+}
+
+func (d *runnersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_runners"
+}
+
+func (d *runnersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = entities.RunnersDataSourceSchema()
+}
+
+func (d *runnersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData != nil {
+		var ok bool
+		var client *clients.Client
+
+		if client, ok = req.ProviderData.(*clients.Client); !ok {
+			resp.Diagnostics.AddError(configResourceError(req.ProviderData))
+			return
+		}
+
+		d.name = "runners"
+		d.client = client
+	}
+}
+
+func (d *runnersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state entities.RunnersDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	runners, err := d.client.ListRunners(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			dataSourceReadError(d.name, err.Error()),
+		)
+		return
+	}
+
+	runnerType := state.RunnerType.ValueString()
+	healthyOnly := state.HealthyOnly.ValueBool()
+
+	state.Runners = make([]entities.RunnerDataModel, 0, len(runners))
+	for _, r := range runners {
+		if runnerType != "" && !strings.EqualFold(r.RunnerType.ValueString(), runnerType) {
+			continue
+		}
+
+		if healthyOnly && !r.Healthy.ValueBool() {
+			continue
+		}
+
+		state.Runners = append(state.Runners, *r)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
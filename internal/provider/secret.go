@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 
 	"terraform-provider-kubiya/internal/clients"
@@ -10,8 +11,9 @@ import (
 )
 
 var (
-	_ resource.Resource              = (*secretResource)(nil)
-	_ resource.ResourceWithConfigure = (*secretResource)(nil)
+	_ resource.Resource                = (*secretResource)(nil)
+	_ resource.ResourceWithConfigure   = (*secretResource)(nil)
+	_ resource.ResourceWithImportState = (*secretResource)(nil)
 )
 
 type secretResource struct {
@@ -32,16 +34,24 @@ func (r *secretResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	timeouts := state.Timeouts
+	ctx, cancel := withTimeout(ctx, timeouts, readAction)
+	defer cancel()
+
 	// Read API call logic
 	if err := r.client.ReadSecret(ctx, &state); err != nil {
-		resp.State.RemoveResource(ctx)
-		// resp.Diagnostics.AddError(
-		// 	"secret not found",
-		// 	fmt.Sprintf("secret by name: %s not found. Error: ", state.Name)+err.Error(),
-		// )
+		if clients.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			resourceActionError(readAction, r.name, err.Error()),
+		)
 		return
 	}
 
+	state.Timeouts = timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -58,6 +68,9 @@ func (r *secretResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, updateAction)
+	defer cancel()
+
 	updatedState := state
 
 	if !plan.Name.IsUnknown() && !plan.Name.IsNull() {
@@ -81,6 +94,7 @@ func (r *secretResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	updatedState.Timeouts = plan.Timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, &updatedState)...)
 }
 
@@ -97,6 +111,9 @@ func (r *secretResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, createAction)
+	defer cancel()
+
 	state, err := r.client.CreateSecret(ctx, &plan)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -106,6 +123,7 @@ func (r *secretResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	state.Timeouts = plan.Timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
 
@@ -118,6 +136,9 @@ func (r *secretResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, state.Timeouts, deleteAction)
+	defer cancel()
+
 	// Delete API call logic
 	r.client.DeleteSecret(ctx, &state)
 	// 	resp.Diagnostics.AddError(
@@ -146,3 +167,7 @@ func (r *secretResource) Configure(_ context.Context, req resource.ConfigureRequ
 		r.client = client
 	}
 }
+
+func (r *secretResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
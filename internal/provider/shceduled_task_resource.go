@@ -11,9 +11,10 @@ import (
 )
 
 var (
-	_ resource.Resource                = (*scheduledTaskResource)(nil)
-	_ resource.ResourceWithConfigure   = (*scheduledTaskResource)(nil)
-	_ resource.ResourceWithImportState = (*scheduledTaskResource)(nil)
+	_ resource.Resource                 = (*scheduledTaskResource)(nil)
+	_ resource.ResourceWithConfigure    = (*scheduledTaskResource)(nil)
+	_ resource.ResourceWithImportState  = (*scheduledTaskResource)(nil)
+	_ resource.ResourceWithUpgradeState = (*scheduledTaskResource)(nil)
 )
 
 type scheduledTaskResource struct {
@@ -25,7 +26,31 @@ func NewScheduledTaskResource() resource.Resource {
 	return &scheduledTaskResource{}
 }
 
-func (r *scheduledTaskResource) Update(_ context.Context, _ resource.UpdateRequest, _ *resource.UpdateResponse) {
+func (r *scheduledTaskResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan entities.ScheduledTaskModel
+	var state entities.ScheduledTaskModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, updateAction)
+	defer cancel()
+
+	plan.Id = state.Id
+
+	updatedState, err := r.client.UpdateScheduledTask(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			resourceActionError(updateAction, r.name, err.Error()),
+		)
+		return
+	}
+
+	updatedState.Timeouts = plan.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &updatedState)...)
 }
 
 func (r *scheduledTaskResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -37,15 +62,25 @@ func (r *scheduledTaskResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
+	timeouts := state.Timeouts
+	ctx, cancel := withTimeout(ctx, timeouts, readAction)
+	defer cancel()
+
 	id := state.Id.ValueString()
-	updatedState, err := r.client.ReadScheduledTask(ctx, id)
+	updatedState, err := r.client.ReadScheduledTask(ctx, id, &state)
 	if err != nil {
+		if clients.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			resourceActionError(readAction, r.name, err.Error()),
 		)
 		return
 	}
 
+	updatedState.Timeouts = timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, &updatedState)...)
 }
 
@@ -63,6 +98,9 @@ func (r *scheduledTaskResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, state.Timeouts, deleteAction)
+	defer cancel()
+
 	if err := r.client.DeleteScheduledTask(ctx, &state); err != nil {
 		resp.Diagnostics.AddError(
 			resourceActionError(deleteAction, r.name, err.Error()),
@@ -80,6 +118,9 @@ func (r *scheduledTaskResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, createAction)
+	defer cancel()
+
 	state, err := r.client.CreateScheduledTask(ctx, &plan)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -88,6 +129,7 @@ func (r *scheduledTaskResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
+	state.Timeouts = plan.Timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -113,3 +155,24 @@ func (r *scheduledTaskResource) Configure(_ context.Context, req resource.Config
 func (r *scheduledTaskResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+func (r *scheduledTaskResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	prior := entities.ScheduledTaskSchemaV0()
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &prior,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var state entities.ScheduledTaskModelV0
+
+				resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				upgraded := state.Upgrade()
+				resp.Diagnostics.Append(resp.State.Set(ctx, &upgraded)...)
+			},
+		},
+	}
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-kubiya/internal/entities"
+)
+
+func TestScheduledTaskUpgradeState(t *testing.T) {
+	ctx := context.Background()
+
+	// the state of a task written by the first releases, with a channel name in channel_id
+	const raw = `{"id":"t1","uuid":"u1","email":"a@b.c","agent":"deployer","channel_id":"#ops","description":"digest",
+		"repeat":"daily","status":"paused","parameters":{"team":"sre"},"task_type":"scheduled",
+		"scheduled_time":"2025-05-01T09:00:00","next_scheduled_time":"2025-05-02T09:00:00"}`
+
+	r := NewScheduledTaskResource().(resource.ResourceWithUpgradeState)
+	upgrader, ok := r.UpgradeState(ctx)[0]
+	if !ok {
+		t.Fatal("there is no upgrade from version 0")
+	}
+
+	prior, err := tftypes.ValueFromJSONWithOpts([]byte(raw), upgrader.PriorSchema.Type().TerraformType(ctx),
+		tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true})
+	if err != nil {
+		t.Fatalf("the version 0 state does not decode: %v", err)
+	}
+
+	current := entities.ScheduledTaskSchema()
+	req := resource.UpgradeStateRequest{State: &tfsdk.State{Schema: *upgrader.PriorSchema, Raw: prior}}
+	resp := &resource.UpgradeStateResponse{
+		State: tfsdk.State{Schema: current, Raw: tftypes.NewValue(current.Type().TerraformType(ctx), nil)},
+	}
+
+	upgrader.StateUpgrader(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("UpgradeState() = %v", resp.Diagnostics)
+	}
+
+	var state entities.ScheduledTaskModel
+	if diags := resp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("the upgraded state does not match the schema: %v", diags)
+	}
+
+	if state.Channel.ValueString() != "#ops" || !state.ChannelId.IsNull() {
+		t.Errorf("channel = %s, channel_id = %s, want the name moved to channel", state.Channel, state.ChannelId)
+	}
+
+	if state.Enabled.ValueBool() || state.Repeat.ValueString() != "daily" || state.Id.ValueString() != "t1" {
+		t.Errorf("enabled = %s, repeat = %s, id = %s", state.Enabled, state.Repeat, state.Id)
+	}
+
+	if state.Context.ValueString() != "deployer" || state.MessageText.ValueString() != "digest" {
+		t.Errorf("context = %s, message text = %s, want deployer and digest", state.Context, state.MessageText)
+	}
+
+	want := types.DynamicValue(types.ObjectValueMust(
+		map[string]attr.Type{"team": types.StringType},
+		map[string]attr.Value{"team": types.StringValue("sre")},
+	))
+	if !state.Parameters.Equal(want) {
+		t.Errorf("parameters = %v, want %v", state.Parameters, want)
+	}
+
+	if !state.Timezone.IsNull() || state.Timeouts != nil {
+		t.Errorf("timezone = %s, timeouts = %v, want both unset", state.Timezone, state.Timeouts)
+	}
+}
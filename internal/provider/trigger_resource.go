@@ -67,6 +67,9 @@ func (r *triggerResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, createAction)
+	defer cancel()
+
 	// Create the trigger
 	tflog.Debug(ctx, "Creating trigger", map[string]interface{}{
 		"name": plan.Name.ValueString(),
@@ -110,6 +113,10 @@ func (r *triggerResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	timeouts := state.Timeouts
+	ctx, cancel := withTimeout(ctx, timeouts, readAction)
+	defer cancel()
+
 	tflog.Debug(ctx, "Reading trigger", map[string]interface{}{
 		"id":   state.Id.ValueString(),
 		"name": state.Name.ValueString(),
@@ -118,6 +125,14 @@ func (r *triggerResource) Read(ctx context.Context, req resource.ReadRequest, re
 	// Get refreshed trigger value from Kubiya
 	err := r.client.ReadTrigger(ctx, &state)
 	if err != nil {
+		if clients.IsNotFound(err) {
+			tflog.Warn(ctx, "Trigger no longer exists, removing it from state", map[string]interface{}{
+				"id": state.Id.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error Reading Kubiya Trigger",
 			"Could not read Kubiya trigger ID "+state.Id.ValueString()+": "+err.Error(),
@@ -126,6 +141,7 @@ func (r *triggerResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 
 	// Set refreshed state
+	state.Timeouts = timeouts
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -156,6 +172,9 @@ func (r *triggerResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, updateAction)
+	defer cancel()
+
 	// Preserve the ID and workflow_id from state
 	plan.Id = state.Id
 	plan.WorkflowId = state.WorkflowId
@@ -207,6 +226,9 @@ func (r *triggerResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, state.Timeouts, deleteAction)
+	defer cancel()
+
 	tflog.Debug(ctx, "Deleting trigger", map[string]interface{}{
 		"id":   state.Id.ValueString(),
 		"name": state.Name.ValueString(),
@@ -0,0 +1,307 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-kubiya/internal/clients"
+	"terraform-provider-kubiya/internal/entities"
+)
+
+var (
+	_ datasource.DataSource              = (*userDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*userDataSource)(nil)
+
+	_ datasource.DataSource              = (*usersDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*usersDataSource)(nil)
+
+	_ datasource.DataSource              = (*groupDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*groupDataSource)(nil)
+
+	_ datasource.DataSource              = (*groupsDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*groupsDataSource)(nil)
+)
+
+type userDataSource struct {
+	name   string
+	client *clients.Client
+}
+
+func NewUserDataSource() datasource.DataSource {
+	return &userDataSource{}
+}
+
+func (d *userDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+func (d *userDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = entities.UserDataSourceSchema()
+}
+
+func (d *userDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData != nil {
+		var ok bool
+		var client *clients.Client
+
+		if client, ok = req.ProviderData.(*clients.Client); !ok {
+			resp.Diagnostics.AddError(configResourceError(req.ProviderData))
+			return
+		}
+
+		d.name = "user"
+		d.client = client
+	}
+}
+
+func (d *userDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config entities.UserModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := config.Id.ValueString()
+	name := config.Name.ValueString()
+	email := config.Email.ValueString()
+
+	set := slices.DeleteFunc([]string{id, email, name}, func(s string) bool { return s == "" })
+	if len(set) != 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("id"),
+			"Invalid User Lookup", "exactly one of id, email or name must be set")
+		return
+	}
+
+	state, err := d.client.FindUser(ctx, id, email, name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			dataSourceReadError(d.name, err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+type usersDataSource struct {
+	name   string
+	client *clients.Client
+}
+
+func NewUsersDataSource() datasource.DataSource {
+	return &usersDataSource{}
+}
+
+func (d *usersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users"
+}
+
+func (d *usersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = entities.UsersDataSourceSchema()
+}
+
+func (d *usersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData != nil {
+		var ok bool
+		var client *clients.Client
+
+		if client, ok = req.ProviderData.(*clients.Client); !ok {
+			resp.Diagnostics.AddError(configResourceError(req.ProviderData))
+			return
+		}
+
+		d.name = "users"
+		d.client = client
+	}
+}
+
+func (d *usersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state entities.UsersDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var emailRegex *regexp.Regexp
+	if expr := state.EmailRegex.ValueString(); expr != "" {
+		var err error
+		if emailRegex, err = regexp.Compile(expr); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("email_regex"), "Invalid Regular Expression", err.Error())
+			return
+		}
+	}
+
+	users, err := d.client.ListUsers(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			dataSourceReadError(d.name, err.Error()),
+		)
+		return
+	}
+
+	group := state.Group.ValueString()
+
+	state.Users = make([]entities.UserModel, 0, len(users))
+	for _, u := range users {
+		if group != "" && !containsFold(u.Groups.Elements(), group) && !containsFold(u.GroupIds.Elements(), group) {
+			continue
+		}
+
+		if emailRegex != nil && !emailRegex.MatchString(u.Email.ValueString()) {
+			continue
+		}
+
+		state.Users = append(state.Users, *u)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+type groupDataSource struct {
+	name   string
+	client *clients.Client
+}
+
+func NewGroupDataSource() datasource.DataSource {
+	return &groupDataSource{}
+}
+
+func (d *groupDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group"
+}
+
+func (d *groupDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = entities.GroupDataSourceSchema()
+}
+
+func (d *groupDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData != nil {
+		var ok bool
+		var client *clients.Client
+
+		if client, ok = req.ProviderData.(*clients.Client); !ok {
+			resp.Diagnostics.AddError(configResourceError(req.ProviderData))
+			return
+		}
+
+		d.name = "group"
+		d.client = client
+	}
+}
+
+func (d *groupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config entities.GroupModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := config.Id.ValueString()
+	name := config.Name.ValueString()
+
+	if (id == "") == (name == "") {
+		resp.Diagnostics.AddAttributeError(path.Root("id"),
+			"Invalid Group Lookup", "exactly one of id or name must be set")
+		return
+	}
+
+	state, err := d.client.FindGroup(ctx, id, name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			dataSourceReadError(d.name, err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+type groupsDataSource struct {
+	name   string
+	client *clients.Client
+}
+
+func NewGroupsDataSource() datasource.DataSource {
+	return &groupsDataSource{}
+}
+
+func (d *groupsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_groups"
+}
+
+func (d *groupsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = entities.GroupsDataSourceSchema()
+}
+
+func (d *groupsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData != nil {
+		var ok bool
+		var client *clients.Client
+
+		if client, ok = req.ProviderData.(*clients.Client); !ok {
+			resp.Diagnostics.AddError(configResourceError(req.ProviderData))
+			return
+		}
+
+		d.name = "groups"
+		d.client = client
+	}
+}
+
+func (d *groupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state entities.GroupsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if expr := state.NameRegex.ValueString(); expr != "" {
+		var err error
+		if nameRegex, err = regexp.Compile(expr); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid Regular Expression", err.Error())
+			return
+		}
+	}
+
+	groups, err := d.client.ListGroups(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			dataSourceReadError(d.name, err.Error()),
+		)
+		return
+	}
+
+	state.Groups = make([]entities.GroupModel, 0, len(groups))
+	for _, g := range groups {
+		if nameRegex != nil && !nameRegex.MatchString(g.Name.ValueString()) {
+			continue
+		}
+
+		state.Groups = append(state.Groups, *g)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// containsFold reports whether a list of string values holds value, ignoring case.
+func containsFold(values []attr.Value, value string) bool {
+	for _, v := range values {
+		if s, ok := v.(types.String); ok && strings.EqualFold(s.ValueString(), value) {
+			return true
+		}
+	}
+
+	return false
+}
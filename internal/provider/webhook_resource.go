@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
@@ -13,8 +14,9 @@ import (
 )
 
 var (
-	_ resource.Resource              = (*webhookResource)(nil)
-	_ resource.ResourceWithConfigure = (*webhookResource)(nil)
+	_ resource.Resource                = (*webhookResource)(nil)
+	_ resource.ResourceWithConfigure   = (*webhookResource)(nil)
+	_ resource.ResourceWithImportState = (*webhookResource)(nil)
 )
 
 type webhookResource struct {
@@ -35,8 +37,17 @@ func (r *webhookResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	timeouts := state.Timeouts
+	ctx, cancel := withTimeout(ctx, timeouts, readAction)
+	defer cancel()
+
 	// Read API call logic
 	if err := r.client.ReadWebhook(ctx, &state); err != nil {
+		if clients.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"webhook not found",
 			fmt.Sprintf("webhook by name: %s not found. Error: ", state.Name)+err.Error(),
@@ -44,6 +55,19 @@ func (r *webhookResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	// Handle agent field from backend response: convert empty string to null
+	if state.Agent.ValueString() == "" {
+		state.Agent = types.StringNull()
+	}
+
+	// Optional attributes the backend returns as empty strings stay null, as when they were not configured
+	for _, v := range []*types.String{&state.Filter, &state.Source, &state.Runner, &state.TeamName} {
+		if v.ValueString() == "" {
+			*v = types.StringNull()
+		}
+	}
+
+	state.Timeouts = timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -61,6 +85,9 @@ func (r *webhookResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, updateAction)
+	defer cancel()
+
 	updatedState := state
 
 	if !plan.Id.IsUnknown() && !plan.Id.IsNull() {
@@ -99,6 +126,7 @@ func (r *webhookResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	updatedState.Timeouts = plan.Timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, &updatedState)...)
 }
 
@@ -114,6 +142,9 @@ func (r *webhookResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, plan.Timeouts, createAction)
+	defer cancel()
+
 	// Normalize workflow JSON before sending to backend
 	workflow := plan.Workflow.ValueString()
 	if workflow != "" {
@@ -178,6 +209,7 @@ func (r *webhookResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	// Set state
+	state.Timeouts = plan.Timeouts
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 }
@@ -191,6 +223,9 @@ func (r *webhookResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, state.Timeouts, deleteAction)
+	defer cancel()
+
 	// Delete API call logic
 	if err := r.client.DeleteWebhook(ctx, &state); err != nil {
 		resp.Diagnostics.AddError(
@@ -218,3 +253,17 @@ func (r *webhookResource) Configure(_ context.Context, req resource.ConfigureReq
 		r.client = client
 	}
 }
+
+// ImportState accepts the id or, as a fallback, the unique name of the webhook.
+func (r *webhookResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := r.client.WebhookImportId(ctx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			format("Could not find the webhook \"%s\" by id or name. Error: %s", req.ID, err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}